@@ -0,0 +1,95 @@
+package assets
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/go-fonts/dejavu/dejavusanscondensed"
+	"github.com/go-fonts/dejavu/dejavusanscondensedbold"
+	"github.com/go-fonts/dejavu/dejavusanscondensedboldoblique"
+	"github.com/go-fonts/dejavu/dejavusanscondensedoblique"
+	"github.com/go-fonts/dejavu/dejavusansmono"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomedium"
+	"golang.org/x/image/font/gofont/gomediumitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/gomonobolditalic"
+	"golang.org/x/image/font/gofont/gomonoitalic"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+type FontFamily struct {
+	Name       string
+	Title      string
+	Regular    fyne.Resource
+	Bold       fyne.Resource
+	Italic     fyne.Resource
+	BoldItalic fyne.Resource
+	Monospace  fyne.Resource
+}
+
+var (
+	goRegular        = fyne.NewStaticResource("Go-Regular.ttf", goregular.TTF)
+	goBold           = fyne.NewStaticResource("Go-Bold.ttf", gobold.TTF)
+	goItalic         = fyne.NewStaticResource("Go-Italic.ttf", goitalic.TTF)
+	goBoldItalic     = fyne.NewStaticResource("Go-Bold-Italic.ttf", gobolditalic.TTF)
+	goMedium         = fyne.NewStaticResource("Go-Medium.ttf", gomedium.TTF)
+	goMediumItalic   = fyne.NewStaticResource("Go-Medium-Italic.ttf", gomediumitalic.TTF)
+	goMono           = fyne.NewStaticResource("Go-Mono.ttf", gomono.TTF)
+	goMonoBold       = fyne.NewStaticResource("Go-Mono-Bold.ttf", gomonobold.TTF)
+	goMonoItalic     = fyne.NewStaticResource("Go-Mono-Italic.ttf", gomonoitalic.TTF)
+	goMonoBoldItalic = fyne.NewStaticResource("Go-Mono-Bold-Italic.ttf", gomonobolditalic.TTF)
+)
+
+var fontFamilies = []FontFamily{
+	{
+		Name:       "go",
+		Title:      "Go",
+		Regular:    goRegular,
+		Bold:       goBold,
+		Italic:     goItalic,
+		BoldItalic: goBoldItalic,
+		Monospace:  goMono,
+	},
+	{
+		Name:       "go-medium",
+		Title:      "Go Medium",
+		Regular:    goMedium,
+		Bold:       goBold,
+		Italic:     goMediumItalic,
+		BoldItalic: goBoldItalic,
+		Monospace:  goMono,
+	},
+	{
+		Name:       "go-mono",
+		Title:      "Go Mono",
+		Regular:    goMono,
+		Bold:       goMonoBold,
+		Italic:     goMonoItalic,
+		BoldItalic: goMonoBoldItalic,
+		Monospace:  goMono,
+	},
+	{
+		Name:       "dejavu-condensed",
+		Title:      "DejaVu Sans Condensed",
+		Regular:    fyne.NewStaticResource("DejaVuSansCondensed.ttf", dejavusanscondensed.TTF),
+		Bold:       fyne.NewStaticResource("DejaVuSansCondensed-Bold.ttf", dejavusanscondensedbold.TTF),
+		Italic:     fyne.NewStaticResource("DejaVuSansCondensed-Oblique.ttf", dejavusanscondensedoblique.TTF),
+		BoldItalic: fyne.NewStaticResource("DejaVuSansCondensed-BoldOblique.ttf", dejavusanscondensedboldoblique.TTF),
+		Monospace:  fyne.NewStaticResource("DejaVuSansMono.ttf", dejavusansmono.TTF),
+	},
+}
+
+func FontFamilies() []FontFamily {
+	return append([]FontFamily(nil), fontFamilies...)
+}
+
+func LookupFontFamily(name string) (FontFamily, bool) {
+	for _, family := range fontFamilies {
+		if family.Name == name {
+			return family, true
+		}
+	}
+	return FontFamily{}, false
+}
@@ -1,13 +1,204 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"math"
+	"os"
+	"strconv"
+	"time"
 
+	"grompt/internal/content"
+	"grompt/internal/scroll"
 	"grompt/internal/ui"
 )
 
 func main() {
-	if err := ui.Run(); err != nil {
+	options, err := parseArgs(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	if err := ui.Run(options); err != nil {
 		log.Fatal(err)
 	}
 }
+
+func parseArgs(args []string, output io.Writer) (ui.Options, error) {
+	var options ui.Options
+
+	flags := flag.NewFlagSet("grompt", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		fmt.Fprintln(output, "Usage: grompt [flags] [file]")
+		fmt.Fprintln(output)
+		flags.PrintDefaults()
+	}
+
+	speed := flags.Float64("speed", 0, "scroll speed in pixels per second (selects the px unit)")
+	wpm := flags.Float64("wpm", 0, "scroll speed in words per minute (selects the wpm unit)")
+	fontSize := flags.Float64("font-size", 0, "text size in points")
+	font := flags.String("font", "", "font: default, a bundled font name or the path of a TTF/OTF file")
+	wordSpacing := flags.Int("word-spacing", 0, "word spacing multiplier")
+	lineHeight := flags.Float64("line-height", 0, "line height multiplier")
+	letterSpacing := flags.Float64("letter-spacing", 0, "extra letter spacing in em")
+	margin := flags.Int("margin", 0, "side margin in percent of the window width")
+	mirror := flags.Bool("mirror", false, "mirror the text horizontally")
+	flip := flags.Bool("flip", false, "flip the text vertically")
+	colorScheme := flags.String("colors", "", "colour scheme: system, classic, yellow, inverted or custom")
+	flags.BoolVar(&options.FullScreen, "fullscreen", false, "start the window in full screen")
+	flags.StringVar(&options.ConfigPath, "config", "", "path of the config file (default ~/.config/grompt.toml)")
+	flags.BoolVar(&options.StartPlaying, "play", false, "start scrolling as soon as the file is loaded")
+	flags.Func("countdown", "count down before scrolling starts, in seconds or as a duration such as 5s (implies -play)", func(value string) error {
+		countdown, err := parseCountdown(value)
+		if err != nil {
+			return err
+		}
+		options.Countdown = countdown
+		return nil
+	})
+
+	if err := flags.Parse(args); err != nil {
+		return ui.Options{}, err
+	}
+	usageError := func(err error) (ui.Options, error) {
+		fmt.Fprintln(output, err)
+		flags.Usage()
+		return ui.Options{}, err
+	}
+	if flags.NArg() > 1 {
+		return usageError(fmt.Errorf("expected at most one file, got %d", flags.NArg()))
+	}
+	options.File = flags.Arg(0)
+
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	if set["speed"] && set["wpm"] {
+		return usageError(errors.New("use either -speed or -wpm, not both"))
+	}
+	values := flagValues{
+		speed:         *speed,
+		wpm:           *wpm,
+		fontSize:      *fontSize,
+		wordSpacing:   *wordSpacing,
+		lineHeight:    *lineHeight,
+		letterSpacing: *letterSpacing,
+		margin:        *margin,
+	}
+	for _, warning := range clampFlags(set, &values) {
+		fmt.Fprintln(output, "warning:", warning)
+		options.Warnings = append(options.Warnings, warning)
+	}
+
+	overrides := &options.Overrides
+	if set["speed"] {
+		unit := "px"
+		overrides.Speed = &values.speed
+		overrides.SpeedUnit = &unit
+	}
+	if set["wpm"] {
+		unit := "wpm"
+		overrides.WPM = &values.wpm
+		overrides.SpeedUnit = &unit
+	}
+	if set["font-size"] {
+		size := float32(values.fontSize)
+		overrides.FontSize = &size
+	}
+	if set["font"] {
+		overrides.Font = font
+	}
+	if set["word-spacing"] {
+		overrides.WordSpacing = &values.wordSpacing
+	}
+	if set["line-height"] {
+		value := float32(values.lineHeight)
+		overrides.LineHeight = &value
+	}
+	if set["letter-spacing"] {
+		value := float32(values.letterSpacing)
+		overrides.LetterSpacing = &value
+	}
+	if set["margin"] {
+		overrides.Margin = &values.margin
+	}
+	if set["mirror"] {
+		overrides.Mirror = mirror
+	}
+	if set["flip"] {
+		overrides.Flip = flip
+	}
+	if set["colors"] {
+		overrides.ColorScheme = colorScheme
+	}
+	if options.Countdown > 0 {
+		options.StartPlaying = true
+	}
+	return options, nil
+}
+
+type flagValues struct {
+	speed         float64
+	wpm           float64
+	fontSize      float64
+	wordSpacing   int
+	lineHeight    float64
+	letterSpacing float64
+	margin        int
+}
+
+func clampFlags(set map[string]bool, values *flagValues) []string {
+	ranges := []struct {
+		name     string
+		value    float64
+		min, max float64
+		apply    func(float64)
+	}{
+		{"speed", values.speed, scroll.DefaultMinSpeed, scroll.DefaultMaxSpeed, func(v float64) { values.speed = v }},
+		{"wpm", values.wpm, scroll.MinWPM, scroll.MaxWPM, func(v float64) { values.wpm = v }},
+		{"font-size", values.fontSize, float64(ui.MinContentFontSize), float64(ui.MaxContentFontSize), func(v float64) { values.fontSize = v }},
+		{"word-spacing", float64(values.wordSpacing), content.MinWordSpacing, content.MaxWordSpacing, func(v float64) { values.wordSpacing = int(v) }},
+		{"line-height", values.lineHeight, float64(content.MinLineHeight), float64(content.MaxLineHeight), func(v float64) { values.lineHeight = v }},
+		{"letter-spacing", values.letterSpacing, float64(ui.MinLetterSpacing), float64(ui.MaxLetterSpacing), func(v float64) { values.letterSpacing = v }},
+		{"margin", float64(values.margin), ui.MinContentMargin, ui.MaxContentMargin, func(v float64) { values.margin = int(v) }},
+	}
+
+	var warnings []string
+	for _, r := range ranges {
+		if !set[r.name] {
+			continue
+		}
+		clamped := r.value
+		if math.IsNaN(clamped) {
+			clamped = r.min
+		}
+		clamped = math.Max(r.min, math.Min(r.max, clamped))
+		if clamped != r.value {
+			warnings = append(warnings, fmt.Sprintf("-%s %g out of range, clamped to %g", r.name, r.value, clamped))
+			r.apply(clamped)
+		}
+	}
+	return warnings
+}
+
+func parseCountdown(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("invalid countdown %q", value)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid countdown %q", value)
+	}
+	return duration, nil
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"grompt/internal/content"
+	"grompt/internal/scroll"
+	"grompt/internal/ui"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, options ui.Options)
+	}{
+		{
+			name: "no arguments",
+			check: func(t *testing.T, o ui.Options) {
+				if o.File != "" || o.StartPlaying || o.FullScreen || o.Overrides.Speed != nil || o.Overrides.Mirror != nil {
+					t.Fatalf("expected empty options, got %+v", o)
+				}
+			},
+		},
+		{
+			name: "file and flags",
+			args: []string{"-fullscreen", "-mirror", "-font-size", "48", "-config", "/tmp/grompt.toml", "show.md"},
+			check: func(t *testing.T, o ui.Options) {
+				if o.File != "show.md" || !o.FullScreen || o.ConfigPath != "/tmp/grompt.toml" {
+					t.Fatalf("unexpected options %+v", o)
+				}
+				if o.Overrides.Mirror == nil || !*o.Overrides.Mirror || o.Overrides.FontSize == nil || *o.Overrides.FontSize != 48 {
+					t.Fatalf("unexpected overrides %+v", o.Overrides)
+				}
+				if o.Overrides.Flip != nil {
+					t.Fatal("unset flags should not override the config")
+				}
+			},
+		},
+		{
+			name: "speed selects pixels",
+			args: []string{"-speed", "90"},
+			check: func(t *testing.T, o ui.Options) {
+				if *o.Overrides.Speed != 90 || *o.Overrides.SpeedUnit != "px" || o.Overrides.WPM != nil {
+					t.Fatalf("unexpected overrides %+v", o.Overrides)
+				}
+			},
+		},
+		{
+			name: "wpm selects words per minute",
+			args: []string{"-wpm", "150"},
+			check: func(t *testing.T, o ui.Options) {
+				if *o.Overrides.WPM != 150 || *o.Overrides.SpeedUnit != "wpm" || o.Overrides.Speed != nil {
+					t.Fatalf("unexpected overrides %+v", o.Overrides)
+				}
+			},
+		},
+		{
+			name: "countdown implies play",
+			args: []string{"-countdown", "5", "show.md"},
+			check: func(t *testing.T, o ui.Options) {
+				if o.Countdown != 5*time.Second || !o.StartPlaying {
+					t.Fatalf("unexpected options %+v", o)
+				}
+			},
+		},
+		{
+			name: "typography and colours",
+			args: []string{"-word-spacing", "3", "-line-height", "1.4", "-letter-spacing", "0.1", "-margin", "10", "-colors", "Yellow", "-font", "go-mono"},
+			check: func(t *testing.T, o ui.Options) {
+				ov := o.Overrides
+				if *ov.WordSpacing != 3 || *ov.LineHeight != 1.4 || *ov.LetterSpacing != 0.1 || *ov.Margin != 10 {
+					t.Fatalf("unexpected overrides %+v", ov)
+				}
+				if *ov.ColorScheme != "Yellow" || *ov.Font != "go-mono" {
+					t.Fatalf("unexpected overrides %+v", ov)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := parseArgs(tt.args, io.Discard)
+			if err != nil {
+				t.Fatalf("parseArgs: %v", err)
+			}
+			tt.check(t, options)
+		})
+	}
+}
+
+func TestParseArgsRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "two files", args: []string{"a.md", "b.md"}, want: "at most one file"},
+		{name: "speed and wpm", args: []string{"-speed", "60", "-wpm", "150"}, want: "either -speed or -wpm"},
+		{name: "countdown", args: []string{"-countdown", "soon"}, want: `invalid countdown "soon"`},
+		{name: "unknown flag", args: []string{"-speedy", "60"}, want: "-speedy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output strings.Builder
+			if _, err := parseArgs(tt.args, &output); err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(output.String(), tt.want) {
+				t.Fatalf("expected %q in the output, got:\n%s", tt.want, output.String())
+			}
+			if !strings.Contains(output.String(), "Usage: grompt [flags] [file]") {
+				t.Fatalf("expected the usage text, got:\n%s", output.String())
+			}
+		})
+	}
+}
+
+func TestParseArgsClampsOutOfRangeValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		warning string
+		check   func(o ui.Options) bool
+	}{
+		{name: "speed too high", args: []string{"-speed", "500"}, warning: "-speed 500 out of range, clamped to 300", check: func(o ui.Options) bool { return *o.Overrides.Speed == 300 }},
+		{name: "speed too low", args: []string{"-speed", "5"}, warning: "-speed 5 out of range, clamped to 20", check: func(o ui.Options) bool { return *o.Overrides.Speed == 20 }},
+		{name: "not a number", args: []string{"-speed", "NaN"}, warning: "-speed NaN out of range, clamped to 20", check: func(o ui.Options) bool { return *o.Overrides.Speed == 20 }},
+		{name: "wpm", args: []string{"-wpm", "1000"}, warning: "-wpm 1000 out of range", check: func(o ui.Options) bool { return *o.Overrides.WPM == scroll.MaxWPM }},
+		{name: "font size", args: []string{"-font-size", "8"}, warning: "-font-size 8 out of range", check: func(o ui.Options) bool { return *o.Overrides.FontSize == ui.MinContentFontSize }},
+		{name: "word spacing", args: []string{"-word-spacing", "0"}, warning: "-word-spacing 0 out of range, clamped to 1", check: func(o ui.Options) bool { return *o.Overrides.WordSpacing == 1 }},
+		{name: "line height", args: []string{"-line-height", "4"}, warning: "-line-height 4 out of range", check: func(o ui.Options) bool { return *o.Overrides.LineHeight == content.MaxLineHeight }},
+		{name: "letter spacing", args: []string{"-letter-spacing", "-0.1"}, warning: "-letter-spacing -0.1 out of range, clamped to 0", check: func(o ui.Options) bool { return *o.Overrides.LetterSpacing == 0 }},
+		{name: "margin", args: []string{"-margin", "45"}, warning: "-margin 45 out of range", check: func(o ui.Options) bool { return *o.Overrides.Margin == ui.MaxContentMargin }},
+		{name: "colour scheme", args: []string{"-colors", "sepia"}, check: func(o ui.Options) bool { return *o.Overrides.ColorScheme == "sepia" }},
+		{name: "missing font", args: []string{"-font", filepath.Join("missing", "Font-Regular.ttf")}, check: func(o ui.Options) bool { return *o.Overrides.Font != "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output strings.Builder
+			options, err := parseArgs(tt.args, &output)
+			if err != nil {
+				t.Fatalf("parseArgs: %v", err)
+			}
+			if !tt.check(options) {
+				t.Fatalf("unexpected overrides %+v", options.Overrides)
+			}
+			if tt.warning == "" {
+				if len(options.Warnings) != 0 || output.Len() != 0 {
+					t.Fatalf("expected the value to be left to ui.Run, got %v %q", options.Warnings, output.String())
+				}
+				return
+			}
+			if len(options.Warnings) != 1 || !strings.HasPrefix(options.Warnings[0], tt.warning) {
+				t.Fatalf("warnings = %v, want %q", options.Warnings, tt.warning)
+			}
+			if !strings.Contains(output.String(), tt.warning) {
+				t.Fatalf("expected %q in the output, got:\n%s", tt.warning, output.String())
+			}
+		})
+	}
+}
+
+func TestParseArgsHelp(t *testing.T) {
+	if _, err := parseArgs([]string{"-h"}, io.Discard); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", err)
+	}
+}
+
+func TestParseCountdown(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "5", want: 5 * time.Second},
+		{value: "0", want: 0},
+		{value: "5s", want: 5 * time.Second},
+		{value: "1m30s", want: 90 * time.Second},
+		{value: "1500ms", want: 1500 * time.Millisecond},
+		{value: "-3", wantErr: true},
+		{value: "-2s", wantErr: true},
+		{value: "", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCountdown(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("parseCountdown(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
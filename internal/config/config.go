@@ -1,32 +1,80 @@
 package config
 
 import (
-	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
-	"strconv"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/BurntSushi/toml"
 )
 
 const (
-	defaultFileName  = "grompt.conf"
+	defaultFileName  = "grompt.toml"
+	legacyFileName   = "grompt.conf"
 	defaultWriteWait = 250 * time.Millisecond
 )
 
 type FileSettings struct {
-	Speed       *float64
-	FontSize    *float32
-	WordSpacing *int
+	Profile       string
+	Profiles      []string
+	Speed         *float64
+	FontSize      *float32
+	Font          *string
+	WordSpacing   *int
+	LineHeight    *float32
+	LetterSpacing *float32
+	Margin        *int
+	Easing        *string
+	RampMillis    *int
+	SpeedUnit     *string
+	WPM           *float64
+	Mirror        *bool
+	Flip          *bool
+	ColorScheme   *string
+	GuidePosition *string
+	GuideLines    *int
+	GuideFade     *int
+	GuideMarker   *string
+	Foreground    *string
+	Background    *string
+	RemoteAddr    *string
+	RemoteToken   *string
+	OSCAddr       *string
+	OSCFeedback   *string
+	ScriptsDir    *string
+	Keymap        map[string]string
 }
 
+var settingKeys = []string{"speed", "font_size", "font", "word_spacing", "line_height", "letter_spacing", "margin", "easing", "ramp_ms", "speed_unit", "wpm", "mirror", "flip", "color_scheme", "guide_position", "guide_lines", "guide_fade", "guide_marker"}
+
 type Settings struct {
-	Speed       float64
-	FontSize    float32
-	WordSpacing int
+	Profile       string
+	Speed         float64
+	FontSize      float32
+	Font          string
+	WordSpacing   int
+	LineHeight    float32
+	LetterSpacing float32
+	Margin        int
+	Easing        string
+	RampMillis    int
+	SpeedUnit     string
+	WPM           float64
+	Mirror        bool
+	Flip          bool
+	ColorScheme   string
+	GuidePosition string
+	GuideLines    int
+	GuideFade     int
+	GuideMarker   string
 }
 
 func DefaultPath() (string, error) {
@@ -38,107 +86,482 @@ func DefaultPath() (string, error) {
 }
 
 func Load(path string) (FileSettings, []string, error) {
-	file, err := os.Open(path)
+	return LoadProfile(path, "")
+}
+
+func LoadProfile(path, profile string) (FileSettings, []string, error) {
+	doc, warnings, err := readDocument(path)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return FileSettings{}, nil, nil
-		}
-		return FileSettings{}, nil, err
+		return FileSettings{}, warnings, err
 	}
-	defer file.Close()
 
 	settings := FileSettings{}
-	var warnings []string
+	warnings = append(warnings, applyValues(&settings, doc, "", true)...)
 
-	scanner := bufio.NewScanner(file)
-	lineNo := 0
-	for scanner.Scan() {
-		lineNo++
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
-			continue
+	profiles, _ := doc["profiles"].(map[string]any)
+	for name := range profiles {
+		settings.Profiles = append(settings.Profiles, name)
+	}
+	sort.Strings(settings.Profiles)
+
+	if profile == "" {
+		if active, ok := doc["profile"].(string); ok {
+			profile = active
+		} else if value, found := doc["profile"]; found {
+			warnings = append(warnings, fmt.Sprintf("invalid profile=%v ignored", value))
 		}
+	}
+	if profile == "" {
+		return settings, warnings, nil
+	}
 
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			warnings = append(warnings, fmt.Sprintf("line %d ignored (expected key=value)", lineNo))
-			continue
+	table, ok := profiles[profile].(map[string]any)
+	if !ok {
+		warnings = append(warnings, fmt.Sprintf("unknown profile %q ignored", profile))
+		return settings, warnings, nil
+	}
+	settings.Profile = profile
+	warnings = append(warnings, applyValues(&settings, table, "profiles."+profile+".", false)...)
+	return settings, warnings, nil
+}
+
+func readDocument(path string) (map[string]any, []string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && filepath.Base(path) == defaultFileName {
+		legacyPath := filepath.Join(filepath.Dir(path), legacyFileName)
+		if legacyData, legacyErr := os.ReadFile(legacyPath); legacyErr == nil {
+			return migrateLegacy(legacyPath, path, legacyData)
 		}
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]any{}, nil, nil
+		}
+		return nil, nil, err
+	}
 
-		key := strings.TrimSpace(strings.ToLower(parts[0]))
-		value := strings.TrimSpace(parts[1])
-		if value == "" {
-			warnings = append(warnings, fmt.Sprintf("%s is empty and was ignored", key))
-			continue
+	doc := map[string]any{}
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		if filepath.Base(path) == legacyFileName && looksLegacy(string(data)) {
+			return migrateLegacy(path, path, data)
 		}
+		return nil, nil, err
+	}
+	return doc, nil, nil
+}
+
+func applyValues(settings *FileSettings, table map[string]any, prefix string, global bool) []string {
+	var warnings []string
+	invalid := func(key string, value any) {
+		warnings = append(warnings, fmt.Sprintf("invalid %s%s=%v ignored", prefix, key, value))
+	}
 
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := table[key]
 		switch key {
+		case "profile", "profiles":
+			if !global {
+				warnings = append(warnings, fmt.Sprintf("unknown setting %q ignored", prefix+key))
+			}
 		case "speed":
-			parsed, parseErr := strconv.ParseFloat(value, 64)
-			if parseErr != nil {
-				warnings = append(warnings, fmt.Sprintf("invalid speed=%q ignored", value))
-				continue
+			if parsed, ok := floatValue(value); ok {
+				settings.Speed = &parsed
+			} else {
+				invalid(key, value)
 			}
-			settings.Speed = &parsed
 		case "font_size":
-			parsed, parseErr := strconv.ParseFloat(value, 32)
-			if parseErr != nil {
-				warnings = append(warnings, fmt.Sprintf("invalid font_size=%q ignored", value))
-				continue
+			if parsed, ok := floatValue(value); ok {
+				asFloat32 := float32(parsed)
+				settings.FontSize = &asFloat32
+			} else {
+				invalid(key, value)
+			}
+		case "font":
+			if parsed, ok := value.(string); ok {
+				settings.Font = &parsed
+			} else {
+				invalid(key, value)
 			}
-			asFloat32 := float32(parsed)
-			settings.FontSize = &asFloat32
 		case "word_spacing":
-			parsed, parseErr := strconv.Atoi(value)
-			if parseErr != nil {
-				warnings = append(warnings, fmt.Sprintf("invalid word_spacing=%q ignored", value))
+			if parsed, ok := intValue(value); ok {
+				settings.WordSpacing = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "line_height":
+			if parsed, ok := floatValue(value); ok {
+				asFloat32 := float32(parsed)
+				settings.LineHeight = &asFloat32
+			} else {
+				invalid(key, value)
+			}
+		case "letter_spacing":
+			if parsed, ok := floatValue(value); ok {
+				asFloat32 := float32(parsed)
+				settings.LetterSpacing = &asFloat32
+			} else {
+				invalid(key, value)
+			}
+		case "margin":
+			if parsed, ok := intValue(value); ok {
+				settings.Margin = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "easing":
+			if parsed, ok := value.(string); ok {
+				parsed = strings.ToLower(parsed)
+				settings.Easing = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "ramp_ms":
+			if parsed, ok := intValue(value); ok {
+				settings.RampMillis = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "speed_unit":
+			if parsed, ok := value.(string); ok {
+				parsed = strings.ToLower(parsed)
+				settings.SpeedUnit = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "wpm":
+			if parsed, ok := floatValue(value); ok {
+				settings.WPM = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "mirror":
+			if parsed, ok := value.(bool); ok {
+				settings.Mirror = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "flip":
+			if parsed, ok := value.(bool); ok {
+				settings.Flip = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "color_scheme":
+			if parsed, ok := value.(string); ok {
+				parsed = strings.ToLower(parsed)
+				settings.ColorScheme = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "guide_position":
+			if parsed, ok := value.(string); ok {
+				parsed = strings.ToLower(parsed)
+				settings.GuidePosition = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "guide_marker":
+			if parsed, ok := value.(string); ok {
+				parsed = strings.ToLower(parsed)
+				settings.GuideMarker = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "guide_lines":
+			if parsed, ok := intValue(value); ok {
+				settings.GuideLines = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "guide_fade":
+			if parsed, ok := intValue(value); ok {
+				settings.GuideFade = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "foreground":
+			if parsed, ok := value.(string); ok {
+				settings.Foreground = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "background":
+			if parsed, ok := value.(string); ok {
+				settings.Background = &parsed
+			} else {
+				invalid(key, value)
+			}
+		case "remote_addr", "remote_token", "osc_addr", "osc_feedback_addr", "scripts_dir":
+			parsed, ok := value.(string)
+			switch {
+			case !global:
+				warnings = append(warnings, fmt.Sprintf("%s%s cannot be set in a profile and was ignored", prefix, key))
+			case !ok:
+				invalid(key, value)
+			case key == "remote_addr":
+				settings.RemoteAddr = &parsed
+			case key == "remote_token":
+				settings.RemoteToken = &parsed
+			case key == "osc_addr":
+				settings.OSCAddr = &parsed
+			case key == "scripts_dir":
+				settings.ScriptsDir = &parsed
+			default:
+				settings.OSCFeedback = &parsed
+			}
+		case "keymap":
+			bindings, ok := value.(map[string]any)
+			if !ok {
+				invalid(key, value)
 				continue
 			}
-			settings.WordSpacing = &parsed
+			if settings.Keymap == nil {
+				settings.Keymap = map[string]string{}
+			}
+			for action, binding := range bindings {
+				parsed, ok := keymapValue(binding)
+				if !ok {
+					invalid(key+"."+action, binding)
+					continue
+				}
+				settings.Keymap[strings.ToLower(action)] = parsed
+			}
 		default:
-			warnings = append(warnings, fmt.Sprintf("unknown setting %q ignored", key))
+			warnings = append(warnings, fmt.Sprintf("unknown setting %q ignored", prefix+key))
 		}
 	}
+	return warnings
+}
 
-	if err := scanner.Err(); err != nil {
-		return FileSettings{}, warnings, err
+func floatValue(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case int64:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	default:
+		return 0, false
 	}
+}
 
-	return settings, warnings, nil
+func intValue(value any) (int, bool) {
+	switch typed := value.(type) {
+	case int64:
+		return int(typed), true
+	case float64:
+		if typed == math.Trunc(typed) {
+			return int(typed), true
+		}
+	}
+	return 0, false
 }
 
+func keymapValue(value any) (string, bool) {
+	switch typed := value.(type) {
+	case string:
+		return typed, true
+	case []any:
+		keys := make([]string, 0, len(typed))
+		for _, item := range typed {
+			key, ok := item.(string)
+			if !ok {
+				return "", false
+			}
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			return "none", true
+		}
+		return strings.Join(keys, ", "), true
+	default:
+		return "", false
+	}
+}
+
+func (s FileSettings) Merge(overrides FileSettings) FileSettings {
+	merged := s
+	if overrides.Speed != nil {
+		merged.Speed = overrides.Speed
+	}
+	if overrides.FontSize != nil {
+		merged.FontSize = overrides.FontSize
+	}
+	if overrides.Font != nil {
+		merged.Font = overrides.Font
+	}
+	if overrides.WordSpacing != nil {
+		merged.WordSpacing = overrides.WordSpacing
+	}
+	if overrides.LineHeight != nil {
+		merged.LineHeight = overrides.LineHeight
+	}
+	if overrides.LetterSpacing != nil {
+		merged.LetterSpacing = overrides.LetterSpacing
+	}
+	if overrides.Margin != nil {
+		merged.Margin = overrides.Margin
+	}
+	if overrides.Easing != nil {
+		merged.Easing = overrides.Easing
+	}
+	if overrides.RampMillis != nil {
+		merged.RampMillis = overrides.RampMillis
+	}
+	if overrides.SpeedUnit != nil {
+		merged.SpeedUnit = overrides.SpeedUnit
+	}
+	if overrides.WPM != nil {
+		merged.WPM = overrides.WPM
+	}
+	if overrides.Mirror != nil {
+		merged.Mirror = overrides.Mirror
+	}
+	if overrides.Flip != nil {
+		merged.Flip = overrides.Flip
+	}
+	if overrides.ColorScheme != nil {
+		merged.ColorScheme = overrides.ColorScheme
+	}
+	if overrides.GuidePosition != nil {
+		merged.GuidePosition = overrides.GuidePosition
+	}
+	if overrides.GuideLines != nil {
+		merged.GuideLines = overrides.GuideLines
+	}
+	if overrides.GuideFade != nil {
+		merged.GuideFade = overrides.GuideFade
+	}
+	if overrides.GuideMarker != nil {
+		merged.GuideMarker = overrides.GuideMarker
+	}
+	if overrides.Foreground != nil {
+		merged.Foreground = overrides.Foreground
+	}
+	if overrides.Background != nil {
+		merged.Background = overrides.Background
+	}
+	if overrides.RemoteAddr != nil {
+		merged.RemoteAddr = overrides.RemoteAddr
+	}
+	if overrides.RemoteToken != nil {
+		merged.RemoteToken = overrides.RemoteToken
+	}
+	if overrides.OSCAddr != nil {
+		merged.OSCAddr = overrides.OSCAddr
+	}
+	if overrides.OSCFeedback != nil {
+		merged.OSCFeedback = overrides.OSCFeedback
+	}
+	if overrides.ScriptsDir != nil {
+		merged.ScriptsDir = overrides.ScriptsDir
+	}
+	if overrides.Keymap != nil {
+		merged.Keymap = overrides.Keymap
+	}
+	return merged
+}
+
+type update func(current []byte) ([]byte, error)
+
 type AsyncWriter struct {
-	path    string
-	wait    time.Duration
-	updates chan Settings
-	stopCh  chan struct{}
-	doneCh  chan struct{}
-	once    sync.Once
+	path       string
+	wait       time.Duration
+	baseline   *Settings
+	onConflict func(keys []string)
+	onError    func(err error)
+	updates    chan update
+
+	mu    sync.Mutex
+	known []byte
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+	once   sync.Once
 }
 
 func NewAsyncWriter(path string) *AsyncWriter {
 	writer := &AsyncWriter{
 		path:    path,
 		wait:    defaultWriteWait,
-		updates: make(chan Settings, 1),
+		updates: make(chan update, 1),
 		stopCh:  make(chan struct{}),
 		doneCh:  make(chan struct{}),
 	}
 
+	writer.known, _ = os.ReadFile(path)
+	if writer.known == nil {
+		writer.known = []byte{}
+	}
+
 	go writer.loop()
 	return writer
 }
 
+func (w *AsyncWriter) SetConflictHandler(handler func(keys []string)) {
+	w.onConflict = handler
+}
+
+func (w *AsyncWriter) SetErrorHandler(handler func(err error)) {
+	w.onError = handler
+}
+
+func (w *AsyncWriter) Matches(data []byte) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return bytes.Equal(w.known, data)
+}
+
+func (w *AsyncWriter) Accept(data []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.known = append([]byte{}, data...)
+}
+
+func (w *AsyncWriter) SetBaseline(settings Settings) {
+	w.baseline = &settings
+}
+
 func (w *AsyncWriter) Save(settings Settings) {
+	baseline := w.baseline
+	onConflict := w.onConflict
+	w.enqueue(func(current []byte) ([]byte, error) {
+		w.mu.Lock()
+		known := w.known
+		w.mu.Unlock()
+
+		var external []string
+		if !bytes.Equal(known, current) {
+			external = externalChanges(known, current, settings.Profile)
+			if len(external) > 0 && onConflict != nil {
+				onConflict(external)
+			}
+		}
+		return updateSettings(current, baseline, settings, external)
+	})
+}
+
+func (w *AsyncWriter) SaveProfile(profile string) {
+	w.enqueue(func(current []byte) ([]byte, error) {
+		return updateProfile(current, profile)
+	})
+}
+
+func (w *AsyncWriter) enqueue(next update) {
 	select {
-	case w.updates <- settings:
+	case w.updates <- next:
 	default:
 		select {
 		case <-w.updates:
 		default:
 		}
 		select {
-		case w.updates <- settings:
+		case w.updates <- next:
 		default:
 		}
 	}
@@ -162,17 +585,16 @@ func (w *AsyncWriter) loop() {
 		}
 	}
 
-	var pending *Settings
+	var pending update
 
 	for {
 		select {
-		case update := <-w.updates:
-			next := update
-			pending = &next
+		case next := <-w.updates:
+			pending = next
 			resetTimer(timer, w.wait)
 		case <-timer.C:
 			if pending != nil {
-				_ = writeAtomic(w.path, *pending)
+				w.flush(pending)
 				pending = nil
 			}
 		case <-w.stopCh:
@@ -182,8 +604,13 @@ func (w *AsyncWriter) loop() {
 				default:
 				}
 			}
+			select {
+			case next := <-w.updates:
+				pending = next
+			default:
+			}
 			if pending != nil {
-				_ = writeAtomic(w.path, *pending)
+				w.flush(pending)
 			}
 			return
 		}
@@ -200,7 +627,169 @@ func resetTimer(timer *time.Timer, delay time.Duration) {
 	timer.Reset(delay)
 }
 
-func writeAtomic(path string, settings Settings) error {
+func (w *AsyncWriter) flush(next update) {
+	if err := w.write(next); err != nil && w.onError != nil {
+		w.onError(err)
+	}
+}
+
+func (w *AsyncWriter) write(next update) error {
+	current, err := os.ReadFile(w.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	content, err := next(current)
+	if err != nil {
+		return err
+	}
+	if err := writeAtomic(w.path, content); err != nil {
+		return err
+	}
+	w.Accept(content)
+	return nil
+}
+
+func updateSettings(current []byte, baseline *Settings, settings Settings, external []string) ([]byte, error) {
+	doc := map[string]any{}
+	if _, err := toml.Decode(string(current), &doc); err != nil {
+		return nil, err
+	}
+
+	skip := map[string]bool{}
+	for _, key := range external {
+		skip[key] = true
+	}
+
+	var edits []valueEdit
+	var tablePath []string
+	table := map[string]any{}
+	if settings.Profile != "" {
+		if !skip["profile"] && !sameValue(doc["profile"], settings.Profile) {
+			edits = append(edits, valueEdit{key: "profile", value: settings.Profile})
+		}
+		tablePath = []string{"profiles", settings.Profile}
+		profiles, _ := doc["profiles"].(map[string]any)
+		if profile, ok := profiles[settings.Profile].(map[string]any); ok {
+			table = profile
+		}
+	}
+
+	var unchanged map[string]any
+	if baseline != nil {
+		unchanged = baseline.values()
+	}
+	values := settings.values()
+	for _, key := range settingKeys {
+		if skip[key] {
+			continue
+		}
+		effective, ok := table[key]
+		if !ok {
+			effective, ok = doc[key]
+		}
+		if !ok && unchanged != nil && sameValue(unchanged[key], values[key]) {
+			continue
+		}
+		if !ok && unchanged == nil {
+			edits = append(edits, valueEdit{key: key, value: values[key]})
+		} else if !ok || !sameValue(effective, values[key]) {
+			edits = append(edits, valueEdit{table: tablePath, key: key, value: values[key]})
+		}
+	}
+
+	if len(edits) == 0 {
+		return current, nil
+	}
+	return applyEdits(current, edits)
+}
+
+func updateProfile(current []byte, profile string) ([]byte, error) {
+	doc := map[string]any{}
+	if _, err := toml.Decode(string(current), &doc); err != nil {
+		return nil, err
+	}
+	if sameValue(doc["profile"], profile) {
+		return current, nil
+	}
+	return applyEdits(current, []valueEdit{{key: "profile", value: profile}})
+}
+
+func externalChanges(known, current []byte, profile string) []string {
+	before := map[string]any{}
+	after := map[string]any{}
+	if _, err := toml.Decode(string(known), &before); err != nil {
+		return nil
+	}
+	if _, err := toml.Decode(string(current), &after); err != nil {
+		return nil
+	}
+
+	var changed []string
+	if !reflect.DeepEqual(before["profile"], after["profile"]) {
+		changed = append(changed, "profile")
+	}
+	for _, key := range settingKeys {
+		if !reflect.DeepEqual(effectiveValue(before, profile, key), effectiveValue(after, profile, key)) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
+
+func effectiveValue(doc map[string]any, profile, key string) any {
+	if profile != "" {
+		profiles, _ := doc["profiles"].(map[string]any)
+		table, _ := profiles[profile].(map[string]any)
+		if value, ok := table[key]; ok {
+			return value
+		}
+	}
+	return doc[key]
+}
+
+func (s Settings) values() map[string]any {
+	return map[string]any{
+		"speed":          int64(math.Round(s.Speed)),
+		"font_size":      int64(math.Round(float64(s.FontSize))),
+		"font":           s.Font,
+		"word_spacing":   int64(s.WordSpacing),
+		"line_height":    math.Round(float64(s.LineHeight)*100) / 100,
+		"letter_spacing": math.Round(float64(s.LetterSpacing)*1000) / 1000,
+		"margin":         int64(s.Margin),
+		"easing":         s.Easing,
+		"ramp_ms":        int64(s.RampMillis),
+		"speed_unit":     s.SpeedUnit,
+		"wpm":            int64(math.Round(s.WPM)),
+		"mirror":         s.Mirror,
+		"flip":           s.Flip,
+		"color_scheme":   s.ColorScheme,
+		"guide_position": s.GuidePosition,
+		"guide_lines":    int64(s.GuideLines),
+		"guide_fade":     int64(s.GuideFade),
+		"guide_marker":   s.GuideMarker,
+	}
+}
+
+func sameValue(existing, value any) bool {
+	switch typed := value.(type) {
+	case int64:
+		number, ok := floatValue(existing)
+		return ok && number == float64(typed)
+	case float64:
+		number, ok := floatValue(existing)
+		return ok && number == typed
+	case string:
+		text, ok := existing.(string)
+		return ok && strings.EqualFold(text, typed)
+	case bool:
+		flag, ok := existing.(bool)
+		return ok && flag == typed
+	default:
+		return false
+	}
+}
+
+func writeAtomic(path string, content []byte) error {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
@@ -211,8 +800,7 @@ func writeAtomic(path string, settings Settings) error {
 		return err
 	}
 
-	content := fmt.Sprintf("speed=%.0f\nfont_size=%.0f\nword_spacing=%d\n", settings.Speed, settings.FontSize, settings.WordSpacing)
-	if _, err = tmp.WriteString(content); err != nil {
+	if _, err = tmp.Write(content); err != nil {
 		_ = tmp.Close()
 		_ = os.Remove(tmp.Name())
 		return err
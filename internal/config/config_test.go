@@ -0,0 +1,626 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testSettings() Settings {
+	return Settings{
+		Speed:         60,
+		FontSize:      42,
+		Font:          "default",
+		WordSpacing:   2,
+		LineHeight:    1,
+		Easing:        "ease-in-out",
+		RampMillis:    300,
+		SpeedUnit:     "px",
+		WPM:           150,
+		Mirror:        false,
+		Flip:          false,
+		ColorScheme:   "system",
+		GuidePosition: "center",
+		GuideLines:    3,
+		GuideFade:     90,
+		GuideMarker:   "chevrons",
+	}
+}
+
+func TestUpdateSettingsKeepsCommentsAndUnknownKeys(t *testing.T) {
+	current := `# Studio prompter
+speed = 60 # tuned for the A-cam
+font_size = 42
+word_spacing = 2
+easing = "ease-in-out"
+ramp_ms = 300
+speed_unit = "px"
+wpm = 150
+mirror = false
+flip = false
+font = "default"
+line_height = 1.0
+letter_spacing = 0.0
+margin = 0
+color_scheme = "system"
+guide_position = "center"
+guide_lines = 3
+guide_fade = 90
+guide_marker = "chevrons"
+future_option = "kept"
+
+# Pedal
+[keymap]
+play_pause = ["Space", "B"]
+
+[plugins.lower_thirds]
+enabled = true
+`
+	settings := testSettings()
+	settings.Speed = 75
+	settings.Mirror = true
+
+	updated, err := updateSettings([]byte(current), nil, settings, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	want := strings.Replace(current, "speed = 60 # tuned", "speed = 75 # tuned", 1)
+	want = strings.Replace(want, "mirror = false", "mirror = true", 1)
+	if string(updated) != want {
+		t.Fatalf("unexpected update:\n%s", updated)
+	}
+}
+
+func TestUpdateSettingsBaselineSkipsMissingKeys(t *testing.T) {
+	current := `# Studio prompter
+speed = 60 # tuned for the A-cam
+future_option = "kept"
+
+[keymap]
+play_pause = ["Space", "B"]
+`
+	baseline := testSettings()
+	settings := baseline
+	settings.Speed = 75
+	settings.Mirror = true
+
+	updated, err := updateSettings([]byte(current), &baseline, settings, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	want := strings.Replace(current, "speed = 60 # tuned for the A-cam\n", "speed = 75 # tuned for the A-cam\n", 1)
+	want = strings.Replace(want, "future_option = \"kept\"\n", "future_option = \"kept\"\nmirror = true\n", 1)
+	if string(updated) != want {
+		t.Fatalf("unexpected update:\n%s", updated)
+	}
+}
+
+func TestUpdateSettingsUnchangedIsIdentical(t *testing.T) {
+	current := "#  odd   spacing\nspeed   =   60.0\nfont_size=42\nword_spacing = 2\neasing = 'EASE-IN-OUT'\nramp_ms = 300\nspeed_unit = \"px\"\nwpm = 150\nmirror = false\nflip = false\n"
+	baseline := testSettings()
+	updated, err := updateSettings([]byte(current), &baseline, testSettings(), nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if string(updated) != current {
+		t.Fatalf("expected an unchanged file, got:\n%s", updated)
+	}
+}
+
+func TestUpdateSettingsWritesActiveProfile(t *testing.T) {
+	current := `speed = 60
+font_size = 42
+
+[profiles.studio]
+mirror = true # beam splitter
+"custom key" = 1
+
+[profiles.studio.keymap]
+rewind = "none"
+`
+	baseline := testSettings()
+	settings := baseline
+	settings.Profile = "studio"
+	settings.Mirror = true
+	settings.Flip = true
+	settings.FontSize = 48
+
+	updated, err := updateSettings([]byte(current), &baseline, settings, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	want := `speed = 60
+font_size = 42
+profile = "studio"
+
+[profiles.studio]
+mirror = true # beam splitter
+"custom key" = 1
+font_size = 48
+flip = true
+
+[profiles.studio.keymap]
+rewind = "none"
+`
+	if string(updated) != want {
+		t.Fatalf("unexpected update:\n%s", updated)
+	}
+
+	path := filepath.Join(t.TempDir(), defaultFileName)
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, _, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if loaded.Profile != "studio" || *loaded.FontSize != 48 || !*loaded.Flip || !*loaded.Mirror || *loaded.Speed != 60 {
+		t.Fatalf("unexpected round trip %+v", loaded)
+	}
+	if loaded.Keymap["rewind"] != "none" {
+		t.Fatalf("expected the profile keymap to survive, got %v", loaded.Keymap)
+	}
+}
+
+func TestUpdateSettingsAddsMissingKeysAndTables(t *testing.T) {
+	current := "# Migrated from grompt.conf by grompt.\n\n[keymap]\nhelp = \"F2\"\n"
+	baseline := testSettings()
+	settings := baseline
+	settings.Profile = "laptop"
+	settings.Speed = 75
+
+	updated, err := updateSettings([]byte(current), &baseline, settings, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if string(updated) != "# Migrated from grompt.conf by grompt.\n\nprofile = \"laptop\"\n\n[keymap]\nhelp = \"F2\"\n\n[profiles.laptop]\nspeed = 75\n" {
+		t.Fatalf("unexpected layout:\n%s", updated)
+	}
+
+	unsaved, err := updateSettings([]byte(current), nil, settings, nil)
+	if err != nil {
+		t.Fatalf("update without baseline: %v", err)
+	}
+	if !strings.HasPrefix(string(unsaved), "# Migrated from grompt.conf by grompt.\n\nprofile = \"laptop\"\nspeed = 75\nfont_size = 42\n") ||
+		strings.Contains(string(unsaved), "[profiles.laptop]") {
+		t.Fatalf("missing keys should go to the base settings:\n%s", unsaved)
+	}
+
+	empty, err := updateSettings(nil, nil, testSettings(), nil)
+	if err != nil {
+		t.Fatalf("update empty: %v", err)
+	}
+	if !strings.HasPrefix(string(empty), "speed = 60\nfont_size = 42\n") {
+		t.Fatalf("unexpected new file:\n%s", empty)
+	}
+}
+
+func TestUpdateSettingsWritesFloatValues(t *testing.T) {
+	current := "speed = 60\nline_height = 1.50\n"
+	baseline := testSettings()
+	baseline.LineHeight = 1.5
+	settings := baseline
+	settings.LetterSpacing = 0.04
+
+	updated, err := updateSettings([]byte(current), &baseline, settings, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if string(updated) != "speed = 60\nline_height = 1.50\nletter_spacing = 0.04\n" {
+		t.Fatalf("unexpected update:\n%s", updated)
+	}
+
+	settings.LineHeight = 2
+	updated, err = updateSettings(updated, &baseline, settings, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !strings.Contains(string(updated), "line_height = 2.0\n") {
+		t.Fatalf("expected a float literal, got:\n%s", updated)
+	}
+}
+
+func TestUpdateSettingsSkipsMultilineValues(t *testing.T) {
+	current := "notes = \"\"\"\nspeed = 1\n\"\"\"\nsizes = [\n  1,\n  2,\n]\nspeed = 60\n"
+	settings := testSettings()
+	settings.Speed = 90
+
+	updated, err := updateSettings([]byte(current), nil, settings, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !strings.HasPrefix(string(updated), "notes = \"\"\"\nspeed = 1\n\"\"\"\nsizes = [\n  1,\n  2,\n]\nspeed = 90\n") {
+		t.Fatalf("unexpected update:\n%s", updated)
+	}
+}
+
+func TestUpdateSettingsExtendsDottedProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		want    string
+	}{
+		{
+			name:    "top level",
+			current: "speed = 60\nprofiles.studio.speed = 90\n\n[keymap]\nhelp = \"F2\"\n",
+			want:    "speed = 60\nprofiles.studio.speed = 90\nprofiles.studio.mirror = true\n\n[keymap]\nhelp = \"F2\"\n",
+		},
+		{
+			name:    "profiles table",
+			current: "speed = 60\n\n[profiles]\nstudio.speed = 90 # rig\nlaptop.speed = 50\n",
+			want:    "speed = 60\n\n[profiles]\nstudio.speed = 90 # rig\nstudio.mirror = true\nlaptop.speed = 50\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := testSettings()
+			settings := baseline
+			settings.Profile = "studio"
+			settings.Speed = 90
+			settings.Mirror = true
+
+			updated, err := updateSettings([]byte("profile = \"studio\"\n"+tt.current), &baseline, settings, nil)
+			if err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if string(updated) != "profile = \"studio\"\n"+tt.want {
+				t.Fatalf("unexpected update:\n%s", updated)
+			}
+		})
+	}
+}
+
+func TestUpdateSettingsRejectsInlineProfiles(t *testing.T) {
+	for _, current := range []string{
+		"profile = \"studio\"\nprofiles = { studio = { speed = 90 } }\n",
+		"profile = \"studio\"\n\n[profiles]\nstudio = { speed = 90 }\n",
+	} {
+		baseline := testSettings()
+		settings := baseline
+		settings.Profile = "studio"
+		settings.Mirror = true
+
+		if _, err := updateSettings([]byte(current), &baseline, settings, nil); !errors.Is(err, errInlineTable) {
+			t.Fatalf("expected an inline table error for %q, got %v", current, err)
+		}
+	}
+}
+
+func TestAsyncWriterPreservesExternalContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), defaultFileName)
+	if err := os.WriteFile(path, []byte("# mine\nspeed = 60\nunknown = [1, 2]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := NewAsyncWriter(path)
+	writer.SetBaseline(testSettings())
+	settings := testSettings()
+	settings.Speed = 80
+	writer.Save(settings)
+	writer.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# mine\nspeed = 80\nunknown = [1, 2]\n" {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+}
+
+func TestAsyncWriterKeepsNewerExternalEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), defaultFileName)
+	if err := os.WriteFile(path, []byte("speed = 60\nfont_size = 42\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := NewAsyncWriter(path)
+	writer.SetBaseline(testSettings())
+	var conflicts []string
+	writer.SetConflictHandler(func(keys []string) {
+		conflicts = keys
+	})
+
+	external := []byte("# edited by hand\nspeed = 99\nfont_size = 42\n")
+	if err := os.WriteFile(path, external, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	settings := testSettings()
+	settings.Speed = 80
+	settings.FontSize = 50
+	writer.Save(settings)
+	writer.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# edited by hand\nspeed = 99\nfont_size = 50\n" {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "speed" {
+		t.Fatalf("expected a speed conflict, got %v", conflicts)
+	}
+	if !writer.Matches(data) {
+		t.Fatal("expected the writer to recognise its own write")
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadReadsTOML(t *testing.T) {
+	path := writeConfig(t, defaultFileName, `speed = 72.5
+font_size = 40
+word_spacing = 3
+easing = "Linear"
+mirror = true
+remote_addr = "127.0.0.1:8080"
+
+[keymap]
+play_pause = ["Space", "B"]
+rewind = "Home"
+`)
+	loaded, warnings, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings %v", warnings)
+	}
+	if *loaded.Speed != 72.5 || *loaded.FontSize != 40 || *loaded.WordSpacing != 3 || !*loaded.Mirror {
+		t.Fatalf("unexpected settings %+v", loaded)
+	}
+	if *loaded.Easing != "linear" || *loaded.RemoteAddr != "127.0.0.1:8080" {
+		t.Fatalf("unexpected strings %q, %q", *loaded.Easing, *loaded.RemoteAddr)
+	}
+	if loaded.Keymap["play_pause"] != "Space, B" || loaded.Keymap["rewind"] != "Home" {
+		t.Fatalf("unexpected keymap %v", loaded.Keymap)
+	}
+	if loaded.Flip != nil || loaded.Profile != "" {
+		t.Fatalf("expected unset values to stay nil, got %+v", loaded)
+	}
+}
+
+func TestLoadWarnsAboutInvalidValues(t *testing.T) {
+	path := writeConfig(t, defaultFileName, "speed = \"fast\"\nmirror = 1\nfuture_option = true\n")
+	loaded, warnings, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if loaded.Speed != nil || loaded.Mirror != nil {
+		t.Fatalf("invalid values should be ignored, got %+v", loaded)
+	}
+	if len(warnings) != 3 {
+		t.Fatalf("expected three warnings, got %v", warnings)
+	}
+}
+
+func TestLoadReadsGuideKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		position string
+		marker   string
+		lines    int
+		fade     int
+		warnings int
+	}{
+		{name: "base", content: "guide_position = \"Top_Third\"\nguide_marker = \"BAR\"\nguide_lines = 4\nguide_fade = 60\n", position: "top_third", marker: "bar", lines: 4, fade: 60},
+		{name: "profile", content: "profile = \"studio\"\nguide_lines = 2\n\n[profiles.studio]\nguide_position = \"center\"\nguide_lines = 5\n", position: "center", lines: 5},
+		{name: "invalid types", content: "guide_position = 1\nguide_marker = true\nguide_lines = \"three\"\nguide_fade = 0.5\n", warnings: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, warnings, err := Load(writeConfig(t, defaultFileName, tt.content))
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if len(warnings) != tt.warnings {
+				t.Fatalf("got warnings %v, want %d", warnings, tt.warnings)
+			}
+			if got := stringOrEmpty(loaded.GuidePosition); got != tt.position {
+				t.Fatalf("guide_position = %q, want %q", got, tt.position)
+			}
+			if got := stringOrEmpty(loaded.GuideMarker); got != tt.marker {
+				t.Fatalf("guide_marker = %q, want %q", got, tt.marker)
+			}
+			if got := intOrZero(loaded.GuideLines); got != tt.lines {
+				t.Fatalf("guide_lines = %d, want %d", got, tt.lines)
+			}
+			if got := intOrZero(loaded.GuideFade); got != tt.fade {
+				t.Fatalf("guide_fade = %d, want %d", got, tt.fade)
+			}
+		})
+	}
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func intOrZero(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func TestLoadProfileOverlaysBaseSettings(t *testing.T) {
+	path := writeConfig(t, defaultFileName, `profile = "studio"
+speed = 60
+font_size = 42
+
+[keymap]
+help = "F2"
+
+[profiles.studio]
+speed = 90
+mirror = true
+
+[profiles.studio.keymap]
+help = "F3"
+
+[profiles.laptop]
+font_size = 30
+`)
+	loaded, warnings, err := Load(path)
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("load: %v %v", err, warnings)
+	}
+	if loaded.Profile != "studio" || *loaded.Speed != 90 || *loaded.FontSize != 42 || !*loaded.Mirror {
+		t.Fatalf("unexpected studio settings %+v", loaded)
+	}
+	if loaded.Keymap["help"] != "F3" {
+		t.Fatalf("expected the profile keymap to win, got %v", loaded.Keymap)
+	}
+	if strings.Join(loaded.Profiles, ",") != "laptop,studio" {
+		t.Fatalf("unexpected profiles %v", loaded.Profiles)
+	}
+
+	laptop, _, err := LoadProfile(path, "laptop")
+	if err != nil {
+		t.Fatalf("load laptop: %v", err)
+	}
+	if laptop.Profile != "laptop" || *laptop.Speed != 60 || *laptop.FontSize != 30 || laptop.Mirror != nil {
+		t.Fatalf("unexpected laptop settings %+v", laptop)
+	}
+
+	missing, warnings, err := LoadProfile(path, "rehearsal")
+	if err != nil {
+		t.Fatalf("load missing profile: %v", err)
+	}
+	if missing.Profile != "" || *missing.Speed != 60 || len(warnings) != 1 {
+		t.Fatalf("expected base settings and a warning, got %+v %v", missing, warnings)
+	}
+}
+
+func TestLoadProfileRejectsGlobalOnlyKeys(t *testing.T) {
+	path := writeConfig(t, defaultFileName, `profile = "studio"
+remote_addr = ":8080"
+
+[profiles.studio]
+remote_addr = ":9090"
+osc_addr = ":9000"
+scripts_dir = "/tmp"
+profile = "laptop"
+`)
+	loaded, warnings, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if *loaded.RemoteAddr != ":8080" || loaded.OSCAddr != nil || loaded.ScriptsDir != nil || loaded.Profile != "studio" {
+		t.Fatalf("profile should not override global-only keys, got %+v", loaded)
+	}
+	if len(warnings) != 4 {
+		t.Fatalf("expected four warnings, got %v", warnings)
+	}
+	for _, warning := range warnings {
+		if !strings.Contains(warning, "profiles.studio.") {
+			t.Fatalf("warning %q should name the profile key", warning)
+		}
+	}
+}
+
+func TestLoadMigratesLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	legacy := "# old\nspeed=55\nmirror=true\neasing=linear\n[keymap]\nhelp=F2\n"
+	legacyPath := filepath.Join(dir, legacyFileName)
+	if err := os.WriteFile(legacyPath, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, defaultFileName)
+	loaded, _, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if *loaded.Speed != 55 || !*loaded.Mirror || *loaded.Easing != "linear" || loaded.Keymap["help"] != "F2" {
+		t.Fatalf("unexpected migrated settings %+v", loaded)
+	}
+	if _, err := os.Stat(legacyPath + ".bak"); err != nil {
+		t.Fatalf("expected a backup of the old file: %v", err)
+	}
+	reloaded, warnings, err := Load(path)
+	if err != nil || len(warnings) != 0 || *reloaded.Speed != 55 {
+		t.Fatalf("expected a valid TOML file after migration, got %+v %v %v", reloaded, warnings, err)
+	}
+}
+
+func TestLoadMigratesLegacyConfigInPlace(t *testing.T) {
+	path := writeConfig(t, legacyFileName, "speed=55\neasing=linear\n")
+	loaded, _, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if *loaded.Speed != 55 || *loaded.Easing != "linear" {
+		t.Fatalf("unexpected migrated settings %+v", loaded)
+	}
+	if data, err := os.ReadFile(path + ".bak"); err != nil || string(data) != "speed=55\neasing=linear\n" {
+		t.Fatalf("expected the original next to the file, got %q %v", data, err)
+	}
+}
+
+func TestLoadReportsInvalidTOML(t *testing.T) {
+	content := "speed=55\neasing=linear\n"
+	for _, name := range []string{"custom.conf", "custom.toml", "prompter"} {
+		path := writeConfig(t, name, content)
+		if _, _, err := Load(path); err == nil {
+			t.Fatalf("%s: expected a decode error", name)
+		}
+		if data, _ := os.ReadFile(path); string(data) != content {
+			t.Fatalf("%s: file was rewritten:\n%s", name, data)
+		}
+		if _, err := os.Stat(path + ".bak"); !os.IsNotExist(err) {
+			t.Fatalf("%s: unexpected backup", name)
+		}
+	}
+}
+
+func TestUpdateProfileWritesOnlyTheProfileKey(t *testing.T) {
+	current := "speed = 60 # base\n\n[profiles.studio]\nspeed = 90\n"
+	updated, err := updateProfile([]byte(current), "studio")
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if string(updated) != "speed = 60 # base\nprofile = \"studio\"\n\n[profiles.studio]\nspeed = 90\n" {
+		t.Fatalf("unexpected update:\n%s", updated)
+	}
+
+	again, err := updateProfile(updated, "studio")
+	if err != nil || string(again) != string(updated) {
+		t.Fatalf("expected no change, got %v:\n%s", err, again)
+	}
+}
+
+func TestAsyncWriterReportsWriteErrors(t *testing.T) {
+	path := writeConfig(t, defaultFileName, "profile = \"studio\"\nprofiles = { studio = { speed = 90 } }\n")
+
+	writer := NewAsyncWriter(path)
+	baseline := testSettings()
+	writer.SetBaseline(baseline)
+	var reported error
+	writer.SetErrorHandler(func(err error) {
+		reported = err
+	})
+	settings := baseline
+	settings.Profile = "studio"
+	settings.Mirror = true
+	writer.Save(settings)
+	writer.Close()
+
+	if !errors.Is(reported, errInlineTable) {
+		t.Fatalf("expected the inline table error to be reported, got %v", reported)
+	}
+}
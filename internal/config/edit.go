@@ -0,0 +1,391 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+)
+
+var (
+	errMalformedKey = errors.New("malformed key")
+	errInlineTable  = errors.New("is an inline table or a value")
+)
+
+type valueEdit struct {
+	table []string
+	key   string
+	value any
+}
+
+type tomlLine struct {
+	header   bool
+	array    bool
+	path     []string
+	value    int
+	end      int
+	last     int
+	multiple bool
+}
+
+type tomlScanner struct {
+	quote string
+	depth int
+}
+
+func applyEdits(current []byte, edits []valueEdit) ([]byte, error) {
+	text := string(current)
+	newline := "\n"
+	if strings.Contains(text, "\r\n") {
+		newline = "\r\n"
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for _, edit := range edits {
+		literal, err := formatValue(edit.value)
+		if err != nil {
+			return nil, err
+		}
+		lines, err = setValue(lines, edit.table, edit.key, literal)
+		if err != nil {
+			return nil, fmt.Errorf("update config: %w", err)
+		}
+	}
+
+	updated := strings.Join(lines, newline) + newline
+	if _, err := toml.Decode(updated, &map[string]any{}); err != nil {
+		return nil, fmt.Errorf("update config: %w", err)
+	}
+	return []byte(updated), nil
+}
+
+func setValue(lines []string, table []string, key, literal string) ([]string, error) {
+	target := append(append([]string{}, table...), key)
+	parsed := scanLines(lines)
+
+	var section, dottedSection []string
+	inTable := len(table) == 0
+	sectionFound := inTable
+	insertAt := -1
+	firstHeader := -1
+	dottedAt := -1
+	for index, line := range parsed {
+		if line == nil {
+			continue
+		}
+		if line.header {
+			if firstHeader < 0 {
+				firstHeader = index
+			}
+			section = line.path
+			inTable = !line.array && samePath(section, table)
+			if inTable {
+				sectionFound = true
+				insertAt = index + 1
+			}
+			continue
+		}
+		path := append(append([]string{}, section...), line.path...)
+		if !samePath(path, target) {
+			switch {
+			case inTable:
+				insertAt = line.last + 1
+			case len(path) <= len(table) && samePath(path, table[:len(path)]):
+				return nil, fmt.Errorf("%s %w", formatPath(path), errInlineTable)
+			case len(section) <= len(table) && hasPrefix(path, table):
+				dottedAt, dottedSection = line.last+1, section
+			}
+			continue
+		}
+
+		original := lines[index]
+		replaced := original[:line.value] + literal
+		if !line.multiple {
+			replaced += original[line.end:]
+		}
+		return append(append(append([]string{}, lines[:index]...), replaced), lines[line.last+1:]...), nil
+	}
+
+	entry := formatKey(key) + " = " + literal
+	switch {
+	case sectionFound && insertAt >= 0:
+		return insertLines(lines, insertAt, entry), nil
+	case sectionFound && firstHeader >= 0:
+		position := firstHeader
+		for position > 0 && isComment(lines[position-1]) {
+			position--
+		}
+		if position > 0 && strings.TrimSpace(lines[position-1]) != "" {
+			return insertLines(lines, position, "", entry, ""), nil
+		}
+		return insertLines(lines, position, entry, ""), nil
+	case sectionFound:
+		return append(lines, entry), nil
+	case dottedAt >= 0:
+		return insertLines(lines, dottedAt, formatPath(target[len(dottedSection):])+" = "+literal), nil
+	}
+
+	if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+		lines = append(lines, "")
+	}
+	return append(lines, formatHeader(table), entry), nil
+}
+
+func scanLines(lines []string) []*tomlLine {
+	parsed := make([]*tomlLine, len(lines))
+	var scanner tomlScanner
+	for index := 0; index < len(lines); index++ {
+		if scanner.quote != "" || scanner.depth > 0 {
+			scanner.scan(lines[index])
+			continue
+		}
+
+		trimmed := strings.TrimSpace(lines[index])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(lines[index]) - len(strings.TrimLeft(lines[index], " \t"))
+
+		if strings.HasPrefix(trimmed, "[") {
+			array := strings.HasPrefix(trimmed, "[[")
+			inner := strings.TrimLeft(trimmed, "[")
+			path, rest, err := parseKey(inner)
+			if err == nil && strings.HasPrefix(rest, "]") {
+				parsed[index] = &tomlLine{header: true, array: array, path: path}
+			}
+			continue
+		}
+
+		path, rest, err := parseKey(trimmed)
+		if err != nil || !strings.HasPrefix(rest, "=") {
+			continue
+		}
+		valueText := strings.TrimLeft(rest[1:], " \t")
+		start := indent + len(trimmed) - len(valueText)
+		line := &tomlLine{path: path, value: start}
+		parsed[index] = line
+
+		comment := scanner.scan(valueText)
+		end := len(valueText)
+		if comment >= 0 {
+			end = comment
+		}
+		line.end = start + len(strings.TrimRight(valueText[:end], " \t"))
+		for scanner.quote != "" || scanner.depth > 0 {
+			line.multiple = true
+			if index+1 >= len(lines) {
+				break
+			}
+			index++
+			scanner.scan(lines[index])
+		}
+		line.last = index
+	}
+	return parsed
+}
+
+func (s *tomlScanner) scan(text string) int {
+	for index := 0; index < len(text); index++ {
+		rest := text[index:]
+		switch s.quote {
+		case `"""`, `'''`:
+			if s.quote == `"""` && rest[0] == '\\' {
+				index++
+				continue
+			}
+			if strings.HasPrefix(rest, s.quote) {
+				index += len(s.quote) - 1
+				s.quote = ""
+			}
+			continue
+		case `"`:
+			if rest[0] == '\\' {
+				index++
+			} else if rest[0] == '"' {
+				s.quote = ""
+			}
+			continue
+		case `'`:
+			if rest[0] == '\'' {
+				s.quote = ""
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(rest, `"""`), strings.HasPrefix(rest, `'''`):
+			s.quote = rest[:3]
+			index += 2
+		case rest[0] == '"', rest[0] == '\'':
+			s.quote = rest[:1]
+		case rest[0] == '[', rest[0] == '{':
+			s.depth++
+		case rest[0] == ']', rest[0] == '}':
+			s.depth--
+		case rest[0] == '#':
+			return index
+		}
+	}
+	if s.quote == `"` || s.quote == `'` {
+		s.quote = ""
+	}
+	return -1
+}
+
+func parseKey(text string) ([]string, string, error) {
+	var parts []string
+	rest := text
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return nil, "", errMalformedKey
+		}
+
+		switch rest[0] {
+		case '"':
+			end := 1
+			for end < len(rest) && rest[end] != '"' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(rest) {
+				return nil, "", errMalformedKey
+			}
+			part, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				return nil, "", errMalformedKey
+			}
+			parts = append(parts, part)
+			rest = rest[end+1:]
+		case '\'':
+			end := strings.IndexByte(rest[1:], '\'')
+			if end < 0 {
+				return nil, "", errMalformedKey
+			}
+			parts = append(parts, rest[1:end+1])
+			rest = rest[end+2:]
+		default:
+			end := 0
+			for end < len(rest) && isBareKeyChar(rest[end]) {
+				end++
+			}
+			if end == 0 {
+				return nil, "", errMalformedKey
+			}
+			parts = append(parts, rest[:end])
+			rest = rest[end:]
+		}
+
+		rest = strings.TrimLeft(rest, " \t")
+		if !strings.HasPrefix(rest, ".") {
+			return parts, rest, nil
+		}
+		rest = rest[1:]
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
+
+func samePath(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasPrefix(path, prefix []string) bool {
+	return len(path) > len(prefix) && samePath(path[:len(prefix)], prefix)
+}
+
+func insertLines(lines []string, index int, inserted ...string) []string {
+	result := make([]string, 0, len(lines)+len(inserted))
+	result = append(result, lines[:index]...)
+	result = append(result, inserted...)
+	return append(result, lines[index:]...)
+}
+
+func formatHeader(table []string) string {
+	return "[" + formatPath(table) + "]"
+}
+
+func formatPath(path []string) string {
+	parts := make([]string, len(path))
+	for index, part := range path {
+		parts[index] = formatKey(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func formatKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for index := 0; index < len(key); index++ {
+		if !isBareKeyChar(key[index]) {
+			return quoteString(key)
+		}
+	}
+	return key
+}
+
+func formatValue(value any) (string, error) {
+	switch typed := value.(type) {
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case float64:
+		literal := strconv.FormatFloat(typed, 'f', -1, 64)
+		if !strings.Contains(literal, ".") {
+			literal += ".0"
+		}
+		return literal, nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case string:
+		return quoteString(typed), nil
+	default:
+		return "", fmt.Errorf("unsupported config value %T", value)
+	}
+}
+
+func quoteString(value string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f || r == utf8.RuneError:
+			fmt.Fprintf(&builder, `\u%04X`, r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
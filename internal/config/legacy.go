@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+func looksLegacy(data string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			continue
+		}
+		if !strings.Contains(line, "=") {
+			return false
+		}
+	}
+	return true
+}
+
+func parseLegacy(data string) (map[string]any, []string) {
+	doc := map[string]any{}
+	var warnings []string
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	lineNo := 0
+	section := ""
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			if section != "keymap" {
+				warnings = append(warnings, fmt.Sprintf("unknown section [%s] ignored", section))
+			}
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			warnings = append(warnings, fmt.Sprintf("line %d ignored (expected key=value)", lineNo))
+			continue
+		}
+
+		key := strings.TrimSpace(strings.ToLower(parts[0]))
+		value := strings.TrimSpace(parts[1])
+		if value == "" {
+			warnings = append(warnings, fmt.Sprintf("%s is empty and was ignored", key))
+			continue
+		}
+
+		switch section {
+		case "":
+			doc[key] = legacyValue(key, value)
+		case "keymap":
+			keymap, ok := doc["keymap"].(map[string]any)
+			if !ok {
+				keymap = map[string]any{}
+				doc["keymap"] = keymap
+			}
+			keymap[key] = value
+		}
+	}
+	return doc, warnings
+}
+
+func legacyValue(key, value string) any {
+	switch key {
+	case "easing", "speed_unit", "remote_addr", "remote_token", "osc_addr", "osc_feedback_addr":
+		return value
+	case "mirror", "flip":
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+		return value
+	}
+	if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return parsed
+	}
+	if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+		return parsed
+	}
+	if parsed, err := strconv.ParseBool(value); err == nil {
+		return parsed
+	}
+	return value
+}
+
+func migrateLegacy(legacyPath, path string, data []byte) (map[string]any, []string, error) {
+	doc, warnings := parseLegacy(string(data))
+
+	var buffer bytes.Buffer
+	buffer.WriteString("# Migrated from " + legacyFileName + " by grompt.\n\n")
+	if err := toml.NewEncoder(&buffer).Encode(doc); err != nil {
+		return doc, warnings, nil
+	}
+	if legacyPath == path {
+		if err := os.WriteFile(path+".bak", data, 0o644); err != nil {
+			return doc, warnings, nil
+		}
+	}
+	if err := writeAtomic(path, buffer.Bytes()); err != nil {
+		return doc, warnings, nil
+	}
+	if legacyPath != path {
+		_ = os.Rename(legacyPath, legacyPath+".bak")
+	}
+	return doc, warnings, nil
+}
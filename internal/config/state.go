@@ -0,0 +1,99 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	stateFileName          = "grompt-state.json"
+	maxRememberedDocuments = 200
+)
+
+type DocumentState struct {
+	Path        string    `json:"path"`
+	Position    float64   `json:"position"`
+	Speed       float64   `json:"speed"`
+	SpeedUnit   string    `json:"speed_unit"`
+	FontSize    float32   `json:"font_size"`
+	WordSpacing int       `json:"word_spacing"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type State struct {
+	Documents map[string]DocumentState `json:"documents"`
+}
+
+func StatePath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), stateFileName)
+}
+
+func DocumentKey(path string, data []byte) string {
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+	sum := sha256.Sum256(data)
+	return path + "#" + hex.EncodeToString(sum[:8])
+}
+
+func LoadState(path string) (State, error) {
+	state := State{Documents: map[string]DocumentState{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{Documents: map[string]DocumentState{}}, err
+	}
+	if state.Documents == nil {
+		state.Documents = map[string]DocumentState{}
+	}
+	return state, nil
+}
+
+func (s *State) Remember(key string, document DocumentState) {
+	if s.Documents == nil {
+		s.Documents = map[string]DocumentState{}
+	}
+	for existing, remembered := range s.Documents {
+		if existing != key && remembered.Path == document.Path {
+			delete(s.Documents, existing)
+		}
+	}
+	s.Documents[key] = document
+	if len(s.Documents) <= maxRememberedDocuments {
+		return
+	}
+
+	keys := make([]string, 0, len(s.Documents))
+	for existing := range s.Documents {
+		keys = append(keys, existing)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return s.Documents[keys[i]].UpdatedAt.After(s.Documents[keys[j]].UpdatedAt)
+	})
+	for _, stale := range keys[maxRememberedDocuments:] {
+		delete(s.Documents, stale)
+	}
+}
+
+func (w *AsyncWriter) SaveState(state State) {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return
+	}
+	content := append(data, '\n')
+	w.enqueue(func([]byte) ([]byte, error) {
+		return content, nil
+	})
+}
@@ -0,0 +1,87 @@
+package content
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type paragraphSpan struct {
+	text  string
+	start int
+	end   int
+}
+
+func paragraphKey(block *Block) string {
+	return fmt.Sprintf("%s:%d:%s", block.Kind, block.Level, strings.Join(strings.Fields(plainInlines(block.Inlines, "")), " "))
+}
+
+func MapPosition(previous, next *Document, position float32) float32 {
+	if previous == nil || next == nil {
+		return position
+	}
+
+	before := &fyneRenderer{}
+	before.blocks(previous.Blocks, "", true)
+	after := &fyneRenderer{}
+	after.blocks(next.Blocks, "", true)
+	if before.length == 0 || after.length == 0 || len(before.paragraphs) == 0 {
+		return position
+	}
+
+	offset := int(math.Round(float64(position) * float64(before.length)))
+	current := 0
+	for i, paragraph := range before.paragraphs {
+		if paragraph.start <= offset {
+			current = i
+		}
+	}
+
+	matches := map[string][]int{}
+	for i, paragraph := range after.paragraphs {
+		matches[paragraph.text] = append(matches[paragraph.text], i)
+	}
+
+	for distance := 0; distance < len(before.paragraphs); distance++ {
+		candidates := []int{current - distance, current + distance}
+		if distance == 0 {
+			candidates = candidates[:1]
+		}
+		for _, index := range candidates {
+			if index < 0 || index >= len(before.paragraphs) {
+				continue
+			}
+			anchor := before.paragraphs[index]
+			match, ok := nearestMatch(matches[anchor.text], index)
+			if !ok {
+				continue
+			}
+			mapped := after.paragraphs[match].start + offset - anchor.start
+			if mapped < 0 {
+				mapped = 0
+			}
+			if mapped > after.length {
+				mapped = after.length
+			}
+			return float32(mapped) / float32(after.length)
+		}
+	}
+	return position
+}
+
+func nearestMatch(candidates []int, index int) (int, bool) {
+	best, found := 0, false
+	for _, candidate := range candidates {
+		if !found || abs(candidate-index) < abs(best-index) {
+			best, found = candidate, true
+		}
+	}
+	return best, found
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,34 @@
+package content
+
+import (
+	"math"
+	"testing"
+)
+
+func paragraphs(texts ...string) *Document {
+	doc := &Document{}
+	for _, text := range texts {
+		doc.Blocks = append(doc.Blocks, &Block{Kind: BlockParagraph, Inlines: []Inline{{Kind: InlineText, Text: text}}})
+	}
+	return doc
+}
+
+func TestMapPositionKeepsUnchangedParagraph(t *testing.T) {
+	previous := paragraphs("alpha", "bravo", "charlie")
+	next := paragraphs("new intro", "alpha", "bravo edited", "charlie")
+
+	got := MapPosition(previous, next, float32(16)/22)
+	if want := float32(34) / 40; math.Abs(float64(got-want)) > 1e-6 {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMapPositionFallsBackToNearestAnchor(t *testing.T) {
+	previous := paragraphs("alpha", "bravo", "charlie")
+	next := paragraphs("alpha", "bravo, rewritten", "charlie")
+
+	got := MapPosition(previous, next, float32(9)/22)
+	if want := float32(9) / 33; math.Abs(float64(got-want)) > 1e-6 {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,144 @@
+package content
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CueKind string
+
+const (
+	CuePause CueKind = "pause"
+	CueSpeed CueKind = "speed"
+	CueWait  CueKind = "wait"
+)
+
+const (
+	firstCueMarker rune = 0x100000
+	lastCueMarker  rune = 0x10FFFD
+)
+
+type Cue struct {
+	Kind    CueKind
+	Speed   float64
+	Wait    time.Duration
+	Segment int
+}
+
+var cueDirectivePattern = regexp.MustCompile(`(?i)<!--\s*([a-z]+(?:\s+[0-9.]+[a-z]*)?)\s*-->|\[\[\s*([a-z]+(?:\s+[0-9.]+[a-z]*)?)\s*\]\]`)
+
+func extractCues(source string, cues []Cue) (string, []Cue) {
+	stripped := cueDirectivePattern.ReplaceAllStringFunc(source, func(match string) string {
+		groups := cueDirectivePattern.FindStringSubmatch(match)
+		directive := groups[1]
+		if directive == "" {
+			directive = groups[2]
+		}
+		cue, ok := parseCueDirective(directive)
+		marker := firstCueMarker + rune(len(cues))
+		if !ok || marker > lastCueMarker {
+			return match
+		}
+		cues = append(cues, cue)
+		return string(marker)
+	})
+	return stripped, cues
+}
+
+func parseCueDirective(directive string) (Cue, bool) {
+	fields := strings.Fields(strings.ToLower(directive))
+	if len(fields) == 0 {
+		return Cue{}, false
+	}
+
+	switch CueKind(fields[0]) {
+	case CuePause:
+		if len(fields) != 1 {
+			return Cue{}, false
+		}
+		return Cue{Kind: CuePause}, true
+	case CueSpeed:
+		if len(fields) != 2 {
+			return Cue{}, false
+		}
+		speed, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || speed <= 0 {
+			return Cue{}, false
+		}
+		return Cue{Kind: CueSpeed, Speed: speed}, true
+	case CueWait:
+		if len(fields) != 2 {
+			return Cue{}, false
+		}
+		value := fields[1]
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			value += "s"
+		}
+		wait, err := time.ParseDuration(value)
+		if err != nil || wait <= 0 {
+			return Cue{}, false
+		}
+		return Cue{Kind: CueWait, Wait: wait}, true
+	default:
+		return Cue{}, false
+	}
+}
+
+func extractInlineCues(doc *Document) []Cue {
+	var cues []Cue
+	doc.Walk(func(block *Block) {
+		for i := range block.Inlines {
+			inline := &block.Inlines[i]
+			if inline.Kind != InlineText && inline.Kind != InlineLink {
+				continue
+			}
+			inline.Text, cues = extractCues(inline.Text, cues)
+		}
+	})
+	return cues
+}
+
+func attachCues(doc *Document, cues []Cue) {
+	doc.Walk(func(block *Block) {
+		inlines := make([]Inline, 0, len(block.Inlines))
+		for _, inline := range block.Inlines {
+			if (inline.Kind != InlineText && inline.Kind != InlineLink) || !strings.ContainsFunc(inline.Text, isCueMarker) {
+				inlines = append(inlines, inline)
+				continue
+			}
+
+			var text strings.Builder
+			flush := func() {
+				if text.Len() > 0 {
+					piece := inline
+					piece.Text = text.String()
+					inlines = append(inlines, piece)
+					text.Reset()
+				}
+			}
+			previous, collapse := rune(0), false
+			for _, r := range inline.Text {
+				index := int(r - firstCueMarker)
+				if !isCueMarker(r) || index >= len(cues) {
+					if !collapse || r != ' ' {
+						text.WriteRune(r)
+						previous = r
+					}
+					collapse = false
+					continue
+				}
+				flush()
+				inlines = append(inlines, Inline{Kind: InlineCue, Cue: cues[index]})
+				collapse = previous == ' '
+			}
+			flush()
+		}
+		block.Inlines = inlines
+	})
+}
+
+func isCueMarker(r rune) bool {
+	return r >= firstCueMarker && r <= lastCueMarker
+}
@@ -0,0 +1,133 @@
+package content
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestExtractCues(t *testing.T) {
+	source, cues := extractCues("Intro\n\n<!-- pause -->\n\nBody [[SPEED 80]] text <!-- wait 3s --> <!-- todo --> [[wait 2]]", nil)
+
+	want := []Cue{
+		{Kind: CuePause},
+		{Kind: CueSpeed, Speed: 80},
+		{Kind: CueWait, Wait: 3 * time.Second},
+		{Kind: CueWait, Wait: 2 * time.Second},
+	}
+	if len(cues) != len(want) {
+		t.Fatalf("expected %d cues, got %+v", len(want), cues)
+	}
+	for i := range want {
+		if cues[i] != want[i] {
+			t.Fatalf("cue %d: expected %+v, got %+v", i, want[i], cues[i])
+		}
+	}
+
+	wantSource := "Intro\n\n\U00100000\n\nBody \U00100001 text \U00100002 <!-- todo --> \U00100003"
+	if source != wantSource {
+		t.Fatalf("expected source %q, got %q", wantSource, source)
+	}
+}
+
+func TestParseAttachesCues(t *testing.T) {
+	doc, err := Parse([]byte("Intro\n\n<!-- pause -->\n\nBody [[speed 80]] text"), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := doc.PlainText(); got != "Intro\n\nBody text\n" {
+		t.Fatalf("expected cue markers to be removed, got %q", got)
+	}
+	if cues := doc.Cues(); len(cues) != 2 || cues[0].Kind != CuePause || cues[1].Speed != 80 {
+		t.Fatalf("unexpected cues %+v", cues)
+	}
+
+	renderer := &fyneRenderer{}
+	renderer.blocks(doc.Blocks, "", true)
+	if len(renderer.cues) != 2 {
+		t.Fatalf("expected 2 cues, got %+v", renderer.cues)
+	}
+	if renderer.cues[0].Segment != 2 || renderer.cues[1].Segment != 4 {
+		t.Fatalf("unexpected cue segments %v and %v", renderer.cues[0].Segment, renderer.cues[1].Segment)
+	}
+	if text := renderer.segments[4].(*widget.TextSegment).Text; text != "text" {
+		t.Fatalf("expected the speed cue before %q, got %q", "text", text)
+	}
+}
+
+func TestParseMatchesCuesToTheirMarkers(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		format Format
+		want   []Inline
+	}{
+		{
+			name:   "image before a cue",
+			source: "![A chart](chart.png) [[pause]] Body",
+			format: FormatMarkdown,
+			want: []Inline{
+				{Kind: InlineText, Text: "A chart "},
+				{Kind: InlineCue, Cue: Cue{Kind: CuePause}},
+				{Kind: InlineText, Text: "Body"},
+			},
+		},
+		{
+			name:   "dropped marker",
+			source: "<script>[[pause]]</script><p>Two [[speed 90]] three</p>",
+			format: FormatHTML,
+			want: []Inline{
+				{Kind: InlineText, Text: "Two "},
+				{Kind: InlineCue, Cue: Cue{Kind: CueSpeed, Speed: 90}},
+				{Kind: InlineText, Text: "three"},
+			},
+		},
+		{
+			name:   "marker lookalike",
+			source: "Plane \U00100005 text [[wait 2]] end",
+			format: FormatText,
+			want: []Inline{
+				{Kind: InlineText, Text: "Plane \U00100005 text "},
+				{Kind: InlineCue, Cue: Cue{Kind: CueWait, Wait: 2 * time.Second}},
+				{Kind: InlineText, Text: "end"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.source), tt.format)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var got []Inline
+			doc.Walk(func(block *Block) {
+				got = append(got, block.Inlines...)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseAttachesCuesInBinaryFormats(t *testing.T) {
+	document := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Hello [[wait 2]] there</w:t></w:r></w:p>
+</w:body></w:document>`
+
+	doc, err := Parse([]byte(zipArchive(t, map[string]string{"word/document.xml": document})), FormatDOCX)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []Inline{
+		{Kind: InlineText, Text: "Hello "},
+		{Kind: InlineCue, Cue: Cue{Kind: CueWait, Wait: 2 * time.Second}},
+		{Kind: InlineText, Text: "there"},
+	}
+	if got := doc.Blocks[0].Inlines; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
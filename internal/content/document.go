@@ -0,0 +1,219 @@
+package content
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type BlockKind string
+
+const (
+	BlockParagraph BlockKind = "paragraph"
+	BlockHeading   BlockKind = "heading"
+	BlockList      BlockKind = "list"
+	BlockCode      BlockKind = "code"
+	BlockQuote     BlockKind = "quote"
+	BlockRule      BlockKind = "rule"
+)
+
+type InlineKind string
+
+const (
+	InlineText  InlineKind = "text"
+	InlineLink  InlineKind = "link"
+	InlineBreak InlineKind = "break"
+	InlineCue   InlineKind = "cue"
+)
+
+type Alignment string
+
+const (
+	AlignLeading  Alignment = ""
+	AlignCenter   Alignment = "center"
+	AlignTrailing Alignment = "trailing"
+)
+
+type Metadata struct {
+	Format Format
+	Title  string
+	Fields map[string]string
+}
+
+type Document struct {
+	Metadata Metadata
+	Blocks   []*Block
+}
+
+type Block struct {
+	Kind     BlockKind
+	Level    int
+	Align    Alignment
+	Inlines  []Inline
+	Ordered  bool
+	Start    int
+	Items    []*ListItem
+	Children []*Block
+}
+
+type ListItem struct {
+	Blocks []*Block
+}
+
+type textStyle struct {
+	bold      bool
+	italic    bool
+	monospace bool
+}
+
+func (s textStyle) text(text string) Inline {
+	return Inline{Kind: InlineText, Text: text, Bold: s.bold, Italic: s.italic, Monospace: s.monospace}
+}
+
+type Inline struct {
+	Kind      InlineKind
+	Text      string
+	URL       string
+	Bold      bool
+	Italic    bool
+	Monospace bool
+	Cue       Cue
+}
+
+func (b *Block) appendInline(inline Inline) {
+	if inline.Kind == InlineText && inline.Text == "" {
+		return
+	}
+	if last := len(b.Inlines) - 1; last >= 0 && inline.Kind == InlineText {
+		previous := &b.Inlines[last]
+		if previous.Kind == InlineText && previous.Bold == inline.Bold && previous.Italic == inline.Italic && previous.Monospace == inline.Monospace {
+			previous.Text += inline.Text
+			return
+		}
+	}
+	b.Inlines = append(b.Inlines, inline)
+}
+
+func (b *Block) appendLines(text string, style textStyle) {
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.Inlines = append(b.Inlines, Inline{Kind: InlineBreak})
+		}
+		b.appendInline(style.text(line))
+	}
+}
+
+func (b *Block) hasVisibleText() bool {
+	for _, inline := range b.Inlines {
+		if (inline.Kind == InlineText || inline.Kind == InlineLink) && strings.TrimSpace(inline.Text) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Document) Walk(visit func(block *Block)) {
+	walkBlocks(d.Blocks, visit)
+}
+
+func walkBlocks(blocks []*Block, visit func(block *Block)) {
+	for _, block := range blocks {
+		visit(block)
+		for _, item := range block.Items {
+			walkBlocks(item.Blocks, visit)
+		}
+		walkBlocks(block.Children, visit)
+	}
+}
+
+func (d *Document) Cues() []Cue {
+	var cues []Cue
+	d.Walk(func(block *Block) {
+		for _, inline := range block.Inlines {
+			if inline.Kind == InlineCue {
+				cues = append(cues, inline.Cue)
+			}
+		}
+	})
+	return cues
+}
+
+func (d *Document) WordCount() int {
+	count := 0
+	d.Walk(func(block *Block) {
+		count += len(strings.Fields(plainInlines(block.Inlines, "")))
+	})
+	return count
+}
+
+func (d *Document) PlainText() string {
+	var b strings.Builder
+	writePlainBlocks(&b, d.Blocks, "", true)
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func writePlainBlocks(b *strings.Builder, blocks []*Block, indent string, spaced bool) {
+	for _, block := range blocks {
+		if !visibleBlock(block) {
+			continue
+		}
+		switch block.Kind {
+		case BlockRule:
+			b.WriteString(indent + "---\n")
+		case BlockList:
+			writePlainList(b, block, indent)
+		case BlockQuote:
+			writePlainBlocks(b, block.Children, indent+"> ", false)
+		default:
+			b.WriteString(indent + plainInlines(block.Inlines, indent) + "\n")
+		}
+		if spaced {
+			b.WriteString("\n")
+		}
+	}
+}
+
+func writePlainList(b *strings.Builder, list *Block, indent string) {
+	for i, item := range list.Items {
+		marker := "- "
+		if list.Ordered {
+			marker = strconv.Itoa(list.Start+i) + ". "
+		}
+		padding := indent + strings.Repeat(" ", utf8.RuneCountInString(marker))
+
+		first := true
+		for _, child := range item.Blocks {
+			if !visibleBlock(child) {
+				continue
+			}
+			if child.Kind == BlockList {
+				writePlainList(b, child, padding)
+				continue
+			}
+
+			var nested strings.Builder
+			writePlainBlocks(&nested, []*Block{child}, "", false)
+			for _, line := range strings.Split(strings.TrimSuffix(nested.String(), "\n"), "\n") {
+				if first {
+					b.WriteString(indent + marker + line + "\n")
+					first = false
+					continue
+				}
+				b.WriteString(padding + line + "\n")
+			}
+		}
+	}
+}
+
+func plainInlines(inlines []Inline, indent string) string {
+	var b strings.Builder
+	for _, inline := range inlines {
+		switch inline.Kind {
+		case InlineText, InlineLink:
+			b.WriteString(strings.ReplaceAll(inline.Text, "\n", "\n"+indent))
+		case InlineBreak:
+			b.WriteString("\n" + indent)
+		}
+	}
+	return b.String()
+}
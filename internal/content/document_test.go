@@ -0,0 +1,178 @@
+package content
+
+import (
+	"reflect"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestParseMarkdownDocument(t *testing.T) {
+	source := "# Opening\n\nGood *evening*\nand **welcome** to [the show](https://example.com).\n\n1. First\n2. Second\n   - Detail\n\n```\nroll tape\n```\n"
+
+	doc, err := parseMarkdown(source)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if doc.Metadata.Title != "Opening" {
+		t.Fatalf("expected title from the first heading, got %q", doc.Metadata.Title)
+	}
+	kinds := make([]BlockKind, 0, len(doc.Blocks))
+	for _, block := range doc.Blocks {
+		kinds = append(kinds, block.Kind)
+	}
+	if want := []BlockKind{BlockHeading, BlockParagraph, BlockList, BlockCode}; !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("expected blocks %v, got %v", want, kinds)
+	}
+
+	wantInlines := []Inline{
+		{Kind: InlineText, Text: "Good "},
+		{Kind: InlineText, Text: "evening", Italic: true},
+		{Kind: InlineText, Text: " and "},
+		{Kind: InlineText, Text: "welcome", Bold: true},
+		{Kind: InlineText, Text: " to "},
+		{Kind: InlineLink, Text: "the show", URL: "https://example.com"},
+		{Kind: InlineText, Text: "."},
+	}
+	if got := doc.Blocks[1].Inlines; !reflect.DeepEqual(got, wantInlines) {
+		t.Fatalf("expected %+v, got %+v", wantInlines, got)
+	}
+
+	list := doc.Blocks[2]
+	if !list.Ordered || list.Start != 1 || len(list.Items) != 2 {
+		t.Fatalf("expected an ordered list with two items, got %+v", list)
+	}
+	if nested := list.Items[1].Blocks[1]; nested.Kind != BlockList || nested.Ordered {
+		t.Fatalf("expected a nested bullet list, got %+v", nested)
+	}
+}
+
+func TestParseMarkdownImageAltText(t *testing.T) {
+	doc, err := parseMarkdown("Before ![A *rising* chart](chart.png) after ![](blank.png)and <span>raw</span> end.\n")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []Inline{
+		{Kind: InlineText, Text: "Before A "},
+		{Kind: InlineText, Text: "rising", Italic: true},
+		{Kind: InlineText, Text: " chart after and raw end."},
+	}
+	if got := doc.Blocks[0].Inlines; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestParseHTMLImageAltText(t *testing.T) {
+	doc, err := parseHTML(`<p>Before <img src="chart.png" alt="A  rising chart"> after<img src="blank.png" alt=""> end.</p>`)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := doc.PlainText(); got != "Before A rising chart after end.\n" {
+		t.Fatalf("unexpected text %q", got)
+	}
+}
+
+func TestParseHTMLDocument(t *testing.T) {
+	source := `<html><head><title>Rundown</title><style>p { color: red }</style></head><body>
+<h2>Opening</h2>
+<p>Good   <b>evening</b>
+  everyone<br>and welcome.</p>
+<ul><li>First<ul><li>Detail</li></ul></li><li><a href="https://example.com">Link</a></li></ul>
+</body></html>`
+
+	doc, err := parseHTML(source)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if doc.Metadata.Title != "Rundown" {
+		t.Fatalf("expected title metadata, got %q", doc.Metadata.Title)
+	}
+	want := "Opening\n\nGood evening everyone\nand welcome.\n\n- First\n  - Detail\n- Link\n"
+	if got := doc.PlainText(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if link := doc.Blocks[2].Items[1].Blocks[0].Inlines[0]; link.Kind != InlineLink || link.URL != "https://example.com" {
+		t.Fatalf("expected a link inline, got %+v", link)
+	}
+}
+
+func TestDocumentWordCount(t *testing.T) {
+	doc := &Document{Blocks: []*Block{
+		{Kind: BlockHeading, Level: 1, Inlines: []Inline{{Kind: InlineText, Text: "Title"}}},
+		{Kind: BlockParagraph, Inlines: []Inline{
+			{Kind: InlineText, Text: "One   two"},
+			{Kind: InlineCue, Cue: Cue{Kind: CuePause}},
+			{Kind: InlineText, Text: " three."},
+		}},
+		{Kind: BlockList, Start: 1, Items: []*ListItem{
+			{Blocks: []*Block{{Kind: BlockParagraph, Inlines: []Inline{{Kind: InlineText, Text: "four"}}}}},
+			{Blocks: []*Block{{Kind: BlockParagraph, Inlines: []Inline{{Kind: InlineLink, Text: "five six"}}}}},
+		}},
+	}}
+
+	if got := doc.WordCount(); got != 7 {
+		t.Fatalf("expected 7 words, got %d", got)
+	}
+}
+
+func TestRenderDocumentSegments(t *testing.T) {
+	doc := &Document{Blocks: []*Block{
+		{Kind: BlockHeading, Level: 1, Inlines: []Inline{{Kind: InlineText, Text: "Title"}}},
+		{Kind: BlockParagraph, Align: AlignCenter, Inlines: []Inline{
+			{Kind: InlineText, Text: "Line", Bold: true},
+			{Kind: InlineBreak},
+			{Kind: InlineLink, Text: "link", URL: "https://example.com"},
+		}},
+		{Kind: BlockList, Ordered: true, Start: 3, Items: []*ListItem{
+			{Blocks: []*Block{{Kind: BlockParagraph, Inlines: []Inline{{Kind: InlineText, Text: "item"}}}}},
+		}},
+		{Kind: BlockRule},
+	}}
+
+	renderer := &fyneRenderer{}
+	renderer.blocks(doc.Blocks, "", true)
+
+	texts := make([]string, 0, len(renderer.segments))
+	for _, segment := range renderer.segments {
+		texts = append(texts, segment.Textual())
+	}
+	want := []string{"Title", "", "", "Line", "", "link", "", "", "3. ", "item", "", "", ""}
+	if !reflect.DeepEqual(texts, want) {
+		t.Fatalf("expected %q, got %q", want, texts)
+	}
+
+	heading := renderer.segments[0].(*widget.TextSegment)
+	if heading.Style.SizeName != ThemeSizeContentHeading || !heading.Style.Inline {
+		t.Fatalf("expected inline heading text, got %+v", heading.Style)
+	}
+	bold := renderer.segments[3].(*widget.TextSegment)
+	if !bold.Style.TextStyle.Bold || bold.Style.Alignment != fyne.TextAlignCenter {
+		t.Fatalf("expected centered bold text, got %+v", bold.Style)
+	}
+	if _, ok := renderer.segments[5].(*widget.HyperlinkSegment); !ok {
+		t.Fatalf("expected a hyperlink segment, got %T", renderer.segments[5])
+	}
+	if _, ok := renderer.segments[12].(*widget.SeparatorSegment); !ok {
+		t.Fatalf("expected a separator segment, got %T", renderer.segments[12])
+	}
+}
+
+func TestOutline(t *testing.T) {
+	doc := &Document{Blocks: []*Block{
+		{Kind: BlockHeading, Level: 1, Inlines: []Inline{{Kind: InlineText, Text: "Intro"}}},
+		{Kind: BlockParagraph, Inlines: []Inline{{Kind: InlineText, Text: "Hello"}}},
+		{Kind: BlockHeading, Level: 2, Inlines: []Inline{{Kind: InlineText, Text: "Main  part"}}},
+	}}
+
+	want := []Section{
+		{Title: "Intro", Level: 1},
+		{Title: "Main part", Level: 2, Segment: 6},
+	}
+	if got := Outline(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
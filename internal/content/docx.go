@@ -0,0 +1,388 @@
+package content
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type docxNumbering map[string]map[int]bool
+
+const maxDOCXStyleDepth = 16
+
+type docxRunProperties struct {
+	bold, italic, monospace          bool
+	hasBold, hasItalic, hasMonospace bool
+}
+
+type docxStyle struct {
+	name       string
+	basedOn    string
+	outline    int
+	hasOutline bool
+	run        docxRunProperties
+}
+
+type docxStyles map[string]docxStyle
+
+func parseDOCX(source string) (*Document, error) {
+	archive, err := openOfficeArchive(source)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := readArchiveFile(archive, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	numbering := docxNumbering{}
+	if data, readErr := readArchiveFile(archive, "word/numbering.xml"); readErr == nil {
+		numbering, err = parseDOCXNumbering(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	styles := docxStyles{}
+	if data, readErr := readArchiveFile(archive, "word/styles.xml"); readErr == nil {
+		styles, err = parseDOCXStyles(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	paragraphs, err := parseDOCXDocument(document, numbering, styles)
+	if err != nil {
+		return nil, err
+	}
+	return officeDocument(paragraphs), nil
+}
+
+func parseDOCXNumbering(data []byte) (docxNumbering, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	abstractFormats := map[string]map[int]bool{}
+	numToAbstract := map[string]string{}
+	var abstractID, numID string
+	level := -1
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse docx numbering: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "abstractNum":
+			abstractID = xmlAttr(start, "abstractNumId")
+			abstractFormats[abstractID] = map[int]bool{}
+		case "lvl":
+			level, _ = strconv.Atoi(xmlAttr(start, "ilvl"))
+		case "numFmt":
+			if formats, exists := abstractFormats[abstractID]; exists && level >= 0 {
+				value := xmlAttr(start, "val")
+				formats[level] = value != "bullet" && value != "none"
+			}
+		case "num":
+			numID = xmlAttr(start, "numId")
+		case "abstractNumId":
+			numToAbstract[numID] = xmlAttr(start, "val")
+		}
+	}
+
+	numbering := docxNumbering{}
+	for num, abstract := range numToAbstract {
+		numbering[num] = abstractFormats[abstract]
+	}
+	return numbering, nil
+}
+
+func parseDOCXStyles(data []byte) (docxStyles, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	styles := docxStyles{}
+	var id string
+	var current *docxStyle
+	conditional := 0
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse docx styles: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local == "tblStylePr" {
+				conditional++
+			}
+			if element.Name.Local == "style" {
+				id = xmlAttr(element, "styleId")
+				current = &docxStyle{}
+				continue
+			}
+			if current == nil || conditional > 0 {
+				continue
+			}
+			switch element.Name.Local {
+			case "name":
+				current.name = xmlAttr(element, "val")
+			case "basedOn":
+				current.basedOn = xmlAttr(element, "val")
+			case "outlineLvl":
+				current.outline, current.hasOutline = docxOutlineLevel(xmlAttr(element, "val")), true
+			case "b":
+				current.run.bold, current.run.hasBold = xmlToggle(element), true
+			case "i":
+				current.run.italic, current.run.hasItalic = xmlToggle(element), true
+			case "rFonts":
+				if font := xmlAttr(element, "ascii"); font != "" {
+					current.run.monospace, current.run.hasMonospace = isMonospaceFont(font), true
+				}
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "tblStylePr":
+				conditional--
+			case "style":
+				if current != nil {
+					styles[id] = *current
+				}
+				current = nil
+			}
+		}
+	}
+	return styles, nil
+}
+
+func (s docxStyles) headingLevel(id string) int {
+	for depth := 0; depth < maxDOCXStyleDepth; depth++ {
+		style, ok := s[id]
+		if !ok {
+			return docxHeadingLevel(id)
+		}
+		if style.hasOutline {
+			return style.outline
+		}
+		if level := docxHeadingLevel(id); level > 0 {
+			return level
+		}
+		if level := docxHeadingLevel(style.name); level > 0 {
+			return level
+		}
+		if style.basedOn == "" {
+			return 0
+		}
+		id = style.basedOn
+	}
+	return 0
+}
+
+func (s docxStyles) applyRun(target *textStyle, id string) {
+	chain := make([]docxRunProperties, 0, 4)
+	for depth := 0; depth < maxDOCXStyleDepth && id != ""; depth++ {
+		style, ok := s[id]
+		if !ok {
+			break
+		}
+		chain = append(chain, style.run)
+		id = style.basedOn
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		chain[i].apply(target)
+	}
+}
+
+func (p docxRunProperties) apply(target *textStyle) {
+	if p.hasBold {
+		target.bold = p.bold
+	}
+	if p.hasItalic {
+		target.italic = p.italic
+	}
+	if p.hasMonospace {
+		target.monospace = p.monospace
+	}
+}
+
+func parseDOCXDocument(data []byte, numbering docxNumbering, styles docxStyles) ([]officeParagraph, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var paragraphs []officeParagraph
+	var current *officeParagraph
+	var numID string
+	var paragraphStyle, runStyle textStyle
+	inRun := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse docx: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "p":
+				current = &officeParagraph{}
+				numID = ""
+				paragraphStyle = textStyle{}
+			case "pStyle":
+				if current != nil {
+					current.heading = styles.headingLevel(xmlAttr(element, "val"))
+					styles.applyRun(&paragraphStyle, xmlAttr(element, "val"))
+				}
+			case "outlineLvl":
+				if current != nil && !inRun {
+					current.heading = docxOutlineLevel(xmlAttr(element, "val"))
+				}
+			case "numPr":
+				if current != nil {
+					current.listed = true
+				}
+			case "ilvl":
+				if current != nil {
+					current.level, _ = strconv.Atoi(xmlAttr(element, "val"))
+				}
+			case "numId":
+				numID = xmlAttr(element, "val")
+			case "r":
+				inRun = true
+				runStyle = paragraphStyle
+			case "b":
+				if inRun {
+					runStyle.bold = xmlToggle(element)
+				}
+			case "i":
+				if inRun {
+					runStyle.italic = xmlToggle(element)
+				}
+			case "rStyle":
+				if inRun {
+					applyNamedCharacterStyle(&runStyle, xmlAttr(element, "val"))
+					styles.applyRun(&runStyle, xmlAttr(element, "val"))
+				}
+			case "rFonts":
+				if inRun {
+					runStyle.monospace = isMonospaceFont(xmlAttr(element, "ascii"))
+				}
+			case "t":
+				var text string
+				if err := decoder.DecodeElement(&text, &element); err != nil {
+					return nil, fmt.Errorf("parse docx: %w", err)
+				}
+				if current != nil {
+					current.appendRun(text, runStyle)
+				}
+			case "tab":
+				if current != nil && inRun {
+					current.appendRun(" ", runStyle)
+				}
+			case "br", "cr":
+				if current != nil {
+					current.appendRun("\n", runStyle)
+				}
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "r":
+				inRun = false
+			case "p":
+				if current == nil {
+					continue
+				}
+				if current.listed {
+					current.ordered = numbering[numID][current.level]
+					if numID == "0" {
+						current.listed = false
+					}
+				}
+				paragraphs = append(paragraphs, *current)
+				current = nil
+			}
+		}
+	}
+	return paragraphs, nil
+}
+
+func docxHeadingLevel(style string) int {
+	normalized := strings.ToLower(strings.ReplaceAll(style, " ", ""))
+	switch normalized {
+	case "title":
+		return 1
+	case "subtitle":
+		return 2
+	}
+	if strings.HasPrefix(normalized, "heading") {
+		if level, err := strconv.Atoi(strings.TrimPrefix(normalized, "heading")); err == nil && level > 0 {
+			return level
+		}
+	}
+	return 0
+}
+
+func docxOutlineLevel(value string) int {
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 0 || level >= 9 {
+		return 0
+	}
+	return level + 1
+}
+
+func xmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func xmlToggle(element xml.StartElement) bool {
+	switch strings.ToLower(xmlAttr(element, "val")) {
+	case "0", "false", "off", "none":
+		return false
+	default:
+		return true
+	}
+}
+
+func isMonospaceFont(name string) bool {
+	lower := strings.ToLower(name)
+	for _, hint := range []string{"courier", "consolas", "mono", "menlo", "monaco"} {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+func applyNamedCharacterStyle(style *textStyle, name string) {
+	normalized := strings.ToLower(strings.NewReplacer(" ", "", "_20_", "").Replace(name))
+	switch normalized {
+	case "strong", "strongemphasis":
+		style.bold = true
+	case "emphasis":
+		style.italic = true
+	case "sourcetext", "htmlcode":
+		style.monospace = true
+	}
+}
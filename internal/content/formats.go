@@ -0,0 +1,45 @@
+package content
+
+import "strings"
+
+type formatSpec struct {
+	format     Format
+	extensions []string
+	binary     bool
+	parse      func(source string) (*Document, error)
+}
+
+var formats = []formatSpec{
+	{format: FormatMarkdown, extensions: []string{".md", ".markdown"}, parse: parseMarkdown},
+	{format: FormatHTML, extensions: []string{".html", ".htm"}, parse: parseHTML},
+	{format: FormatText, extensions: []string{".txt"}, parse: parsePlainText},
+	{format: FormatFountain, extensions: []string{".fountain"}, parse: parseFountain},
+	{format: FormatSubtitles, extensions: []string{".srt", ".vtt"}, parse: parseSubtitles},
+	{format: FormatDOCX, extensions: []string{".docx"}, binary: true, parse: parseDOCX},
+	{format: FormatODT, extensions: []string{".odt"}, binary: true, parse: parseODT},
+}
+
+func SupportedExtensions() []string {
+	extensions := make([]string, 0, len(formats)*2)
+	for _, spec := range formats {
+		extensions = append(extensions, spec.extensions...)
+	}
+	return extensions
+}
+
+func SupportedExtensionsText() string {
+	extensions := SupportedExtensions()
+	if len(extensions) < 2 {
+		return strings.Join(extensions, "")
+	}
+	return strings.Join(extensions[:len(extensions)-1], ", ") + " and " + extensions[len(extensions)-1]
+}
+
+func lookupFormat(format Format) (formatSpec, bool) {
+	for _, spec := range formats {
+		if spec.format == format {
+			return spec, true
+		}
+	}
+	return formatSpec{}, false
+}
@@ -0,0 +1,169 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func blockTexts(doc *Document) []string {
+	texts := make([]string, 0, len(doc.Blocks))
+	for _, block := range doc.Blocks {
+		texts = append(texts, plainInlines(block.Inlines, ""))
+	}
+	return texts
+}
+
+func TestParsePlainText(t *testing.T) {
+	doc, err := parsePlainText("\ufeffFirst line\r\nsecond line\r\n\r\n\r\nNext paragraph  \n")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"First line\nsecond line", "Next paragraph"}
+	if got := blockTexts(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if doc.Blocks[0].Inlines[1].Kind != InlineBreak {
+		t.Fatalf("expected a line break inside the paragraph, got %+v", doc.Blocks[0].Inlines)
+	}
+}
+
+func TestParseFountain(t *testing.T) {
+	source := `Title: The Pilot
+Author: Someone
+
+INT. STUDIO - NIGHT
+
+The lights come up. /* cut this */
+
+ANNA (V.O.)
+(quietly)
+We are *live*.[[check tone]]
+
+CUT TO:
+`
+	doc, err := parseFountain(source)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{
+		"The Pilot", "Someone",
+		"INT. STUDIO - NIGHT",
+		"The lights come up.",
+		"ANNA (V.O.)\n(quietly)\nWe are live.",
+		"CUT TO:",
+	}
+	if got := blockTexts(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if doc.Metadata.Title != "The Pilot" || doc.Metadata.Fields["author"] != "Someone" {
+		t.Fatalf("expected title page metadata, got %+v", doc.Metadata)
+	}
+
+	if scene := doc.Blocks[2]; scene.Kind != BlockHeading || scene.Level != 2 {
+		t.Fatalf("expected scene heading, got %+v", scene)
+	}
+	dialogue := doc.Blocks[4]
+	if dialogue.Align != AlignCenter || !dialogue.Inlines[0].Bold {
+		t.Fatalf("expected centered bold character cue, got %+v", dialogue)
+	}
+	if !dialogue.Inlines[2].Italic {
+		t.Fatalf("expected italic parenthetical, got %+v", dialogue.Inlines[2])
+	}
+	if emphasis := dialogue.Inlines[5]; emphasis.Text != "live" || !emphasis.Italic {
+		t.Fatalf("expected italic emphasis, got %+v", emphasis)
+	}
+	if transition := doc.Blocks[5]; transition.Align != AlignTrailing {
+		t.Fatalf("expected right-aligned transition, got %+v", transition)
+	}
+}
+
+func TestParseSubtitles(t *testing.T) {
+	source := `WEBVTT
+
+NOTE written by hand
+
+1
+00:00:01.000 --> 00:00:02.500
+<v Anna>Good evening,</v>
+
+2
+00:00:02.600 --> 00:00:04.000
+and <i>welcome</i>.
+
+3
+00:00:04.000 --> 00:00:05.000
+and <i>welcome</i>.
+
+4
+00:00:09.000 --> 00:00:10.000
+Tonight's headlines.
+`
+	doc, err := parseSubtitles(source)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"Anna: Good evening, and welcome.", "Tonight's headlines."}
+	if got := blockTexts(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if speaker := doc.Blocks[0].Inlines[0]; speaker.Text != "Anna: " || !speaker.Bold {
+		t.Fatalf("expected bold speaker label, got %+v", speaker)
+	}
+}
+
+func TestParseSubtitlesGolden(t *testing.T) {
+	var inputs []string
+	for _, pattern := range []string{"*.srt", "*.vtt"} {
+		matches, err := filepath.Glob(filepath.Join("testdata", "subtitles", pattern))
+		if err != nil {
+			t.Fatal(err)
+		}
+		inputs = append(inputs, matches...)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no golden inputs found")
+	}
+
+	for _, input := range inputs {
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			source, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc, err := parseSubtitles(string(source))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			got := doc.PlainText()
+
+			golden := strings.TrimSuffix(input, filepath.Ext(input)) + ".golden"
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file: %v", err)
+			}
+			if got != string(want) {
+				t.Fatalf("parsing of %s changed:\n--- want\n%s--- got\n%s", input, want, got)
+			}
+		})
+	}
+}
+
+func TestParseSubtitleTimestamp(t *testing.T) {
+	if got := parseSubtitleTimestamp("01:02:03,500"); got.Seconds() != 3723.5 {
+		t.Fatalf("expected 3723.5s, got %v", got)
+	}
+	if got := parseSubtitleTimestamp("02:03.250"); got.Seconds() != 123.25 {
+		t.Fatalf("expected 123.25s, got %v", got)
+	}
+}
@@ -0,0 +1,198 @@
+package content
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	fountainBoneyardPattern = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	fountainNotePattern     = regexp.MustCompile(`(?s)\[\[.*?\]\]`)
+	fountainEmphasisPattern = regexp.MustCompile(`(\*{1,3}|_)(\S(?:.*?\S)?)(\*{1,3}|_)`)
+	fountainScenePattern    = regexp.MustCompile(`(?i)^(int|ext|est|int\.?/ext|i/e)[. ]`)
+	fountainTitleKeyPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z ]*):(.*)$`)
+)
+
+type fountainParser struct {
+	doc      *Document
+	current  *Block
+	joinable bool
+}
+
+func parseFountain(source string) (*Document, error) {
+	source = normalizeNewlines(source)
+	source = fountainBoneyardPattern.ReplaceAllString(source, "")
+	source = fountainNotePattern.ReplaceAllString(source, "")
+
+	lines := strings.Split(source, "\n")
+	parser := &fountainParser{doc: &Document{}}
+	lines = parser.titlePage(lines)
+
+	inDialogue := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			inDialogue = false
+			parser.current = nil
+			continue
+		}
+
+		if inDialogue {
+			if strings.HasPrefix(trimmed, "(") && strings.HasSuffix(trimmed, ")") {
+				parser.line(trimmed, textStyle{italic: true})
+			} else {
+				parser.line(trimmed, textStyle{})
+			}
+			continue
+		}
+
+		previousBlank := i == 0 || strings.TrimSpace(lines[i-1]) == ""
+		nextBlank := i+1 >= len(lines) || strings.TrimSpace(lines[i+1]) == ""
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, "="):
+			continue
+		case strings.HasPrefix(trimmed, "!"):
+			parser.line(strings.TrimPrefix(trimmed, "!"), textStyle{})
+		case isFountainSceneHeading(trimmed) && previousBlank:
+			heading := strings.TrimPrefix(trimmed, ".")
+			parser.start(&Block{Kind: BlockHeading, Level: 2}, strings.ToUpper(heading), textStyle{}, false)
+		case strings.HasPrefix(trimmed, ">") && strings.HasSuffix(trimmed, "<"):
+			centered := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(trimmed, ">"), "<"))
+			parser.start(&Block{Kind: BlockParagraph, Align: AlignCenter}, centered, textStyle{}, false)
+		case isFountainTransition(trimmed) && previousBlank && nextBlank:
+			transition := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			parser.start(&Block{Kind: BlockParagraph, Align: AlignTrailing}, transition, textStyle{bold: true}, false)
+		case strings.HasPrefix(trimmed, "~"):
+			parser.line(strings.TrimSpace(strings.TrimPrefix(trimmed, "~")), textStyle{italic: true})
+		case isFountainCharacter(trimmed) && previousBlank && !nextBlank:
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(trimmed, "@"), "^"))
+			parser.start(&Block{Kind: BlockParagraph, Align: AlignCenter}, name, textStyle{bold: true}, true)
+			inDialogue = true
+		default:
+			parser.line(trimmed, textStyle{})
+		}
+	}
+
+	return parser.doc, nil
+}
+
+func (p *fountainParser) titlePage(lines []string) []string {
+	if len(lines) == 0 || !fountainTitleKeyPattern.MatchString(lines[0]) {
+		return lines
+	}
+
+	end := 0
+	for end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+		end++
+	}
+
+	p.doc.Metadata.Fields = map[string]string{}
+	key := ""
+	for _, line := range lines[:end] {
+		value := strings.TrimSpace(line)
+		match := fountainTitleKeyPattern.FindStringSubmatch(line)
+		if match != nil {
+			key = strings.ToLower(strings.TrimSpace(match[1]))
+			value = strings.TrimSpace(match[2])
+			p.current = nil
+		}
+		if value == "" {
+			continue
+		}
+
+		if existing := p.doc.Metadata.Fields[key]; existing != "" {
+			p.doc.Metadata.Fields[key] = existing + "\n" + value
+		} else {
+			p.doc.Metadata.Fields[key] = value
+		}
+		if key == "title" && p.doc.Metadata.Title == "" {
+			p.doc.Metadata.Title = fountainEmphasisPattern.ReplaceAllString(value, "$2")
+		}
+
+		if p.current != nil {
+			p.line(value, textStyle{})
+			continue
+		}
+		block := &Block{Kind: BlockParagraph, Align: AlignCenter}
+		if key == "title" {
+			block = &Block{Kind: BlockHeading, Level: 1, Align: AlignCenter}
+		}
+		p.start(block, value, textStyle{}, true)
+	}
+	p.current = nil
+	return lines[end:]
+}
+
+func (p *fountainParser) start(block *Block, text string, style textStyle, joinable bool) {
+	p.doc.Blocks = append(p.doc.Blocks, block)
+	p.current = block
+	p.joinable = joinable
+	appendFountainText(block, text, style)
+}
+
+func (p *fountainParser) line(text string, style textStyle) {
+	if p.current == nil || !p.joinable {
+		p.start(&Block{Kind: BlockParagraph}, text, style, true)
+		return
+	}
+	p.current.Inlines = append(p.current.Inlines, Inline{Kind: InlineBreak})
+	appendFountainText(p.current, text, style)
+}
+
+func appendFountainText(block *Block, text string, style textStyle) {
+	last := 0
+	for _, match := range fountainEmphasisPattern.FindAllStringSubmatchIndex(text, -1) {
+		block.appendInline(style.text(text[last:match[0]]))
+
+		emphasis := style
+		opening, closing := text[match[2]:match[3]], text[match[6]:match[7]]
+		if opening == closing {
+			emphasis.italic = emphasis.italic || opening == "*" || opening == "***"
+			emphasis.bold = emphasis.bold || opening == "**" || opening == "***"
+		}
+		block.appendInline(emphasis.text(text[match[4]:match[5]]))
+		last = match[1]
+	}
+	block.appendInline(style.text(text[last:]))
+}
+
+func isFountainSceneHeading(line string) bool {
+	if strings.HasPrefix(line, ".") && !strings.HasPrefix(line, "..") {
+		return true
+	}
+	return fountainScenePattern.MatchString(line)
+}
+
+func isFountainTransition(line string) bool {
+	if strings.HasPrefix(line, ">") {
+		return true
+	}
+	return strings.HasSuffix(line, "TO:") && isUpperCase(line)
+}
+
+func isFountainCharacter(line string) bool {
+	if strings.HasPrefix(line, "@") {
+		return true
+	}
+	name := line
+	if index := strings.Index(name, "("); index >= 0 {
+		name = name[:index]
+	}
+	name = strings.TrimSpace(strings.TrimSuffix(name, "^"))
+	return name != "" && isUpperCase(name)
+}
+
+func isUpperCase(value string) bool {
+	hasLetter := false
+	for _, r := range value {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			if !unicode.IsUpper(r) {
+				return false
+			}
+		}
+	}
+	return hasLetter
+}
@@ -0,0 +1,420 @@
+package content
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const tableCellSeparator = " · "
+
+type htmlParser struct {
+	doc          *Document
+	blocks       *[]*Block
+	current      *Block
+	preformatted bool
+}
+
+func parseHTML(rawHTML string) (*Document, error) {
+	root, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		return nil, fmt.Errorf("parse html: %w", err)
+	}
+
+	doc := &Document{}
+	parser := &htmlParser{doc: doc, blocks: &doc.Blocks}
+	parser.node(root, textStyle{})
+	return doc, nil
+}
+
+func (p *htmlParser) node(node *html.Node, style textStyle) {
+	switch node.Type {
+	case html.TextNode:
+		if p.preformatted {
+			p.preformattedText(node.Data, style)
+			return
+		}
+		p.text(collapseWhitespace(node.Data), style)
+		return
+	case html.ElementNode:
+		p.element(node, style)
+		return
+	}
+	p.children(node, style)
+}
+
+func (p *htmlParser) element(node *html.Node, style textStyle) {
+	switch node.Data {
+	case "title":
+		if p.doc.Metadata.Title == "" {
+			p.doc.Metadata.Title = strings.TrimSpace(extractText(node))
+		}
+		return
+	case "script", "style", "template":
+		return
+	case "img":
+		if alt := collapseWhitespace(attribute(node, "alt")); strings.TrimSpace(alt) != "" {
+			p.text(alt, style)
+		}
+		return
+	case "br":
+		if p.current != nil {
+			p.current.Inlines = append(p.current.Inlines, Inline{Kind: InlineBreak})
+		}
+		return
+	case "p", "div", "section", "article":
+		p.closeBlock()
+		p.children(node, style)
+		p.closeBlock()
+		return
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		p.closeBlock()
+		p.current = &Block{Kind: BlockHeading, Level: int(node.Data[1] - '0')}
+		*p.blocks = append(*p.blocks, p.current)
+		p.children(node, style)
+		p.closeBlock()
+		return
+	case "strong", "b":
+		next := style
+		next.bold = true
+		p.children(node, next)
+		return
+	case "em", "i":
+		next := style
+		next.italic = true
+		p.children(node, next)
+		return
+	case "code":
+		next := style
+		next.monospace = true
+		p.children(node, next)
+		return
+	case "pre":
+		p.pre(node, style)
+		return
+	case "hr":
+		p.closeBlock()
+		*p.blocks = append(*p.blocks, &Block{Kind: BlockRule})
+		return
+	case "blockquote":
+		p.closeBlock()
+		quote := &Block{Kind: BlockQuote}
+		*p.blocks = append(*p.blocks, quote)
+		nested := &htmlParser{doc: p.doc, blocks: &quote.Children}
+		nested.children(node, style)
+		nested.closeBlock()
+		return
+	case "dl":
+		p.closeBlock()
+		p.definitions(node, style)
+		return
+	case "table":
+		p.closeBlock()
+		p.table(node, style)
+		return
+	case "a":
+		p.link(node, style)
+		return
+	case "ul", "ol":
+		p.closeBlock()
+		p.list(node, node.Data == "ol", style)
+		return
+	case "li", "dt", "dd", "tr", "td", "th", "caption":
+		p.closeBlock()
+		p.children(node, style)
+		p.closeBlock()
+		return
+	}
+
+	p.children(node, style)
+}
+
+func (p *htmlParser) children(node *html.Node, style textStyle) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		p.node(child, style)
+	}
+}
+
+func (p *htmlParser) list(node *html.Node, ordered bool, style textStyle) {
+	list := &Block{Kind: BlockList, Ordered: ordered, Start: 1}
+	if start, err := strconv.Atoi(attribute(node, "start")); err == nil && ordered {
+		list.Start = start
+	}
+	*p.blocks = append(*p.blocks, list)
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.Data != "li" {
+			continue
+		}
+		item := &ListItem{}
+		list.Items = append(list.Items, item)
+
+		nested := &htmlParser{doc: p.doc, blocks: &item.Blocks}
+		nested.children(child, style)
+		nested.closeBlock()
+	}
+}
+
+func (p *htmlParser) pre(node *html.Node, style textStyle) {
+	p.closeBlock()
+	p.current = &Block{Kind: BlockCode}
+	*p.blocks = append(*p.blocks, p.current)
+
+	next := style
+	next.monospace = true
+	p.preformatted = true
+	p.children(node, next)
+	p.preformatted = false
+	if p.current == nil {
+		return
+	}
+
+	inlines := p.current.Inlines
+	for len(inlines) > 0 {
+		last := inlines[len(inlines)-1]
+		if last.Kind != InlineBreak && (last.Kind != InlineText || last.Text != "") {
+			break
+		}
+		inlines = inlines[:len(inlines)-1]
+	}
+	p.current.Inlines = inlines
+	p.current = nil
+}
+
+func (p *htmlParser) preformattedText(text string, style textStyle) {
+	if p.current == nil {
+		p.current = &Block{Kind: BlockCode}
+		*p.blocks = append(*p.blocks, p.current)
+	}
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	p.current.appendLines(text, style)
+}
+
+func (p *htmlParser) definitions(node *html.Node, style textStyle) {
+	list := &Block{Kind: BlockList, Start: 1}
+	var item *ListItem
+	defined := false
+	var visit func(parent *html.Node)
+	visit = func(parent *html.Node) {
+		for child := parent.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.Data {
+			case "div":
+				visit(child)
+			case "dt":
+				if item == nil || defined {
+					item = &ListItem{}
+					list.Items = append(list.Items, item)
+					defined = false
+				}
+				term := style
+				term.bold = true
+				nested := &htmlParser{doc: p.doc, blocks: &item.Blocks}
+				nested.children(child, term)
+				nested.closeBlock()
+			case "dd":
+				if item == nil {
+					item = &ListItem{}
+					list.Items = append(list.Items, item)
+				}
+				nested := &htmlParser{doc: p.doc, blocks: &item.Blocks}
+				nested.children(child, style)
+				nested.closeBlock()
+				defined = true
+			}
+		}
+	}
+	visit(node)
+	if len(list.Items) > 0 {
+		*p.blocks = append(*p.blocks, list)
+	}
+}
+
+func (p *htmlParser) table(node *html.Node, style textStyle) {
+	var rows []*html.Node
+	var visit func(parent *html.Node)
+	visit = func(parent *html.Node) {
+		for child := parent.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.Data {
+			case "caption":
+				caption := style
+				caption.bold = true
+				p.children(child, caption)
+				p.closeBlock()
+			case "thead", "tbody", "tfoot":
+				visit(child)
+			case "tr":
+				rows = append(rows, child)
+			}
+		}
+	}
+	visit(node)
+
+	var headers []string
+	if len(rows) > 1 {
+		cells := tableCells(rows[0])
+		for _, cell := range cells {
+			if cell.Data != "th" {
+				headers = nil
+				break
+			}
+			headers = append(headers, extractText(cell))
+		}
+		if headers != nil {
+			rows = rows[1:]
+		}
+	}
+
+	list := &Block{Kind: BlockList, Start: 1}
+	for _, row := range rows {
+		item := &ListItem{}
+		nested := &htmlParser{doc: p.doc, blocks: &item.Blocks}
+		for index, cell := range tableCells(row) {
+			if extractText(cell) == "" {
+				continue
+			}
+			if index < len(headers) && headers[index] != "" {
+				nested.closeBlock()
+				label := style
+				label.bold = true
+				nested.text(headers[index]+": ", label)
+			} else if headers == nil && nested.current != nil {
+				nested.text(tableCellSeparator, style)
+			}
+			nested.children(cell, style)
+			if headers != nil {
+				nested.closeBlock()
+			}
+		}
+		nested.closeBlock()
+		if len(item.Blocks) > 0 {
+			list.Items = append(list.Items, item)
+		}
+	}
+	if len(list.Items) > 0 {
+		*p.blocks = append(*p.blocks, list)
+	}
+}
+
+func tableCells(row *html.Node) []*html.Node {
+	var cells []*html.Node
+	for child := row.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && (child.Data == "td" || child.Data == "th") {
+			cells = append(cells, child)
+		}
+	}
+	return cells
+}
+
+func attribute(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func (p *htmlParser) link(node *html.Node, style textStyle) {
+	href := attribute(node, "href")
+
+	text := strings.TrimSpace(extractText(node))
+	if text == "" {
+		text = href
+	}
+	if text == "" {
+		return
+	}
+	if href == "" {
+		p.text(text, style)
+		return
+	}
+
+	p.text("", style)
+	inline := style.text(text)
+	inline.Kind = InlineLink
+	inline.URL = href
+	p.current.appendInline(inline)
+}
+
+func (p *htmlParser) text(text string, style textStyle) {
+	if p.current == nil {
+		if strings.TrimSpace(text) == "" && text != "" {
+			return
+		}
+		p.current = &Block{Kind: BlockParagraph}
+		*p.blocks = append(*p.blocks, p.current)
+	}
+	if p.endsWithSpace() {
+		text = strings.TrimLeft(text, " ")
+	}
+	p.current.appendInline(style.text(text))
+}
+
+func (p *htmlParser) endsWithSpace() bool {
+	if len(p.current.Inlines) == 0 {
+		return true
+	}
+	last := p.current.Inlines[len(p.current.Inlines)-1]
+	return last.Kind == InlineBreak || strings.HasSuffix(last.Text, " ")
+}
+
+func (p *htmlParser) closeBlock() {
+	if p.current == nil {
+		return
+	}
+	inlines := p.current.Inlines
+	for i := len(inlines) - 1; i >= 0; i-- {
+		if inlines[i].Kind != InlineText {
+			break
+		}
+		inlines[i].Text = strings.TrimRight(inlines[i].Text, " ")
+		if inlines[i].Text != "" {
+			break
+		}
+		inlines = inlines[:i]
+	}
+	p.current.Inlines = inlines
+	p.current = nil
+}
+
+func collapseWhitespace(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		if value == "" {
+			return ""
+		}
+		return " "
+	}
+
+	collapsed := strings.Join(fields, " ")
+	if strings.TrimLeft(value, " \t\r\n\f") != value {
+		collapsed = " " + collapsed
+	}
+	if strings.TrimRight(value, " \t\r\n\f") != value {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+func extractText(node *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(collapseWhitespace(n.Data))
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,130 @@
+package content
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+func TestRenderHTMLGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "html", "*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no golden inputs found")
+	}
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".html")
+		t.Run(name, func(t *testing.T) {
+			source, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc, err := parseHTML(string(source))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			got := renderedRows(doc)
+
+			golden := strings.TrimSuffix(input, ".html") + ".golden"
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file: %v", err)
+			}
+			if got != string(want) {
+				t.Fatalf("rendering of %s changed:\n--- want\n%s--- got\n%s", input, want, got)
+			}
+		})
+	}
+}
+
+func renderedRows(doc *Document) string {
+	renderer := &fyneRenderer{}
+	renderer.blocks(doc.Blocks, "", true)
+
+	var out strings.Builder
+	var row []widget.RichTextSegment
+	for _, segment := range renderer.segments {
+		switch current := segment.(type) {
+		case *widget.SeparatorSegment:
+			out.WriteString("rule       |\n")
+		case *widget.TextSegment:
+			if current.Style.Inline {
+				row = append(row, current)
+				continue
+			}
+			out.WriteString(rowLabel(current.Style) + "|" + rowText(row, current.Style) + "\n")
+			row = nil
+		default:
+			row = append(row, current)
+		}
+	}
+	return out.String()
+}
+
+func rowLabel(style widget.RichTextStyle) string {
+	label := "paragraph"
+	switch {
+	case style.SizeName == ThemeSizeContentHeading:
+		label = "heading"
+	case style.SizeName == ThemeSizeContentSubheading:
+		label = "subheading"
+	case style.TextStyle.Monospace:
+		label = "code"
+	case style.TextStyle.Bold:
+		label = "minor"
+	}
+	return label + strings.Repeat(" ", 11-len(label))
+}
+
+func rowText(row []widget.RichTextSegment, base widget.RichTextStyle) string {
+	var text strings.Builder
+	for _, segment := range row {
+		switch current := segment.(type) {
+		case *widget.HyperlinkSegment:
+			text.WriteString("[" + current.Text + "](" + current.URL.String() + ")")
+		case *widget.TextSegment:
+			marker := ""
+			if current.Style.TextStyle.Bold && !base.TextStyle.Bold {
+				marker += "**"
+			}
+			if current.Style.TextStyle.Italic && !base.TextStyle.Italic {
+				marker += "_"
+			}
+			if current.Style.TextStyle.Monospace && !base.TextStyle.Monospace {
+				marker += "`"
+			}
+			content := strings.TrimRight(current.Text, " ")
+			trailing := current.Text[len(content):]
+			leading := content[:len(content)-len(strings.TrimLeft(content, " "))]
+			content = content[len(leading):]
+			if content == "" {
+				text.WriteString(current.Text)
+				continue
+			}
+			text.WriteString(leading + marker + content + reverseMarker(marker) + trailing)
+		}
+	}
+	return strings.TrimRight(text.String(), " ")
+}
+
+func reverseMarker(marker string) string {
+	runes := []rune(marker)
+	for left, right := 0, len(runes)-1; left < right; left, right = left+1, right-1 {
+		runes[left], runes[right] = runes[right], runes[left]
+	}
+	return string(runes)
+}
@@ -11,8 +11,13 @@ import (
 type Format string
 
 const (
-	FormatMarkdown Format = "markdown"
-	FormatHTML     Format = "html"
+	FormatMarkdown  Format = "markdown"
+	FormatHTML      Format = "html"
+	FormatText      Format = "text"
+	FormatFountain  Format = "fountain"
+	FormatSubtitles Format = "subtitles"
+	FormatDOCX      Format = "docx"
+	FormatODT       Format = "odt"
 )
 
 var ErrUnsupportedFileType = errors.New("unsupported file type")
@@ -34,12 +39,12 @@ func LoadFromPath(path string) ([]byte, Format, error) {
 func DetectFormat(path string) (Format, error) {
 	ext := strings.ToLower(filepath.Ext(path))
 
-	switch ext {
-	case ".md", ".markdown":
-		return FormatMarkdown, nil
-	case ".html", ".htm":
-		return FormatHTML, nil
-	default:
-		return "", fmt.Errorf("%w: %s", ErrUnsupportedFileType, ext)
+	for _, spec := range formats {
+		for _, candidate := range spec.extensions {
+			if candidate == ext {
+				return spec.format, nil
+			}
+		}
 	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFileType, ext)
 }
@@ -13,7 +13,11 @@ func TestDetectFormat(t *testing.T) {
 		{name: "markdown-long-ext", path: "notes.markdown", want: FormatMarkdown},
 		{name: "html", path: "notes.html", want: FormatHTML},
 		{name: "htm", path: "notes.htm", want: FormatHTML},
-		{name: "unsupported", path: "notes.txt", wantErr: true},
+		{name: "text", path: "notes.TXT", want: FormatText},
+		{name: "fountain", path: "pilot.fountain", want: FormatFountain},
+		{name: "srt", path: "captions.srt", want: FormatSubtitles},
+		{name: "vtt", path: "captions.vtt", want: FormatSubtitles},
+		{name: "unsupported", path: "notes.pdf", wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -35,3 +39,11 @@ func TestDetectFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestSupportedExtensionsMatchDetectFormat(t *testing.T) {
+	for _, ext := range SupportedExtensions() {
+		if _, err := DetectFormat("script" + ext); err != nil {
+			t.Fatalf("expected %s to be detected, got %v", ext, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package content
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+func parseMarkdown(markdown string) (*Document, error) {
+	source := []byte(markdown)
+	root := goldmark.DefaultParser().Parse(text.NewReader(source))
+
+	doc := &Document{Blocks: markdownBlocks(root, source)}
+	for _, block := range doc.Blocks {
+		if block.Kind == BlockHeading && block.Level == 1 {
+			doc.Metadata.Title = strings.TrimSpace(plainInlines(block.Inlines, ""))
+			break
+		}
+	}
+	return doc, nil
+}
+
+func markdownBlocks(parent ast.Node, source []byte) []*Block {
+	var blocks []*Block
+	for node := parent.FirstChild(); node != nil; node = node.NextSibling() {
+		switch current := node.(type) {
+		case *ast.Heading:
+			block := &Block{Kind: BlockHeading, Level: current.Level}
+			markdownInlines(block, current, source, textStyle{})
+			blocks = append(blocks, block)
+		case *ast.Paragraph, *ast.TextBlock:
+			block := &Block{Kind: BlockParagraph}
+			markdownInlines(block, current, source, textStyle{})
+			blocks = append(blocks, block)
+		case *ast.List:
+			block := &Block{Kind: BlockList, Ordered: current.IsOrdered(), Start: 1}
+			if current.IsOrdered() {
+				block.Start = current.Start
+			}
+			for item := current.FirstChild(); item != nil; item = item.NextSibling() {
+				block.Items = append(block.Items, &ListItem{Blocks: markdownBlocks(item, source)})
+			}
+			blocks = append(blocks, block)
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			var code strings.Builder
+			lines := current.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				segment := lines.At(i)
+				code.Write(segment.Value(source))
+			}
+			block := &Block{Kind: BlockCode}
+			block.appendLines(strings.TrimRight(code.String(), "\n"), textStyle{monospace: true})
+			blocks = append(blocks, block)
+		case *ast.Blockquote:
+			blocks = append(blocks, &Block{Kind: BlockQuote, Children: markdownBlocks(current, source)})
+		case *ast.ThematicBreak:
+			blocks = append(blocks, &Block{Kind: BlockRule})
+		}
+	}
+	return blocks
+}
+
+func markdownInlines(block *Block, parent ast.Node, source []byte, style textStyle) {
+	for node := parent.FirstChild(); node != nil; node = node.NextSibling() {
+		switch current := node.(type) {
+		case *ast.Text:
+			block.appendInline(style.text(string(current.Segment.Value(source))))
+			switch {
+			case current.HardLineBreak():
+				block.Inlines = append(block.Inlines, Inline{Kind: InlineBreak})
+			case current.SoftLineBreak():
+				block.appendInline(style.text(" "))
+			}
+		case *ast.String:
+			block.appendInline(style.text(string(current.Value)))
+		case *ast.Emphasis:
+			next := style
+			if current.Level >= 2 {
+				next.bold = true
+			} else {
+				next.italic = true
+			}
+			markdownInlines(block, current, source, next)
+		case *ast.CodeSpan:
+			next := style
+			next.monospace = true
+			markdownInlines(block, current, source, next)
+		case *ast.Link:
+			link := style.text(markdownText(current, source))
+			link.Kind = InlineLink
+			link.URL = string(current.Destination)
+			block.appendInline(link)
+		case *ast.AutoLink:
+			link := style.text(string(current.Label(source)))
+			link.Kind = InlineLink
+			link.URL = string(current.URL(source))
+			block.appendInline(link)
+		case *ast.RawHTML:
+		default:
+			markdownInlines(block, current, source, style)
+		}
+	}
+}
+
+func markdownText(parent ast.Node, source []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(parent, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch current := node.(type) {
+		case *ast.Text:
+			b.Write(current.Segment.Value(source))
+			if current.SoftLineBreak() || current.HardLineBreak() {
+				b.WriteString(" ")
+			}
+		case *ast.String:
+			b.Write(current.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
@@ -0,0 +1,210 @@
+package content
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var odtWhitespacePattern = regexp.MustCompile(`\s+`)
+
+type odtList struct {
+	styleName string
+	freshItem bool
+}
+
+type odtParser struct {
+	textStyles map[string]textStyle
+	listStyles map[string]map[int]bool
+
+	styleName   string
+	listLevel   int
+	styleFamily string
+
+	paragraphs []officeParagraph
+	current    *officeParagraph
+	spans      []textStyle
+	lists      []odtList
+	skipDepth  int
+}
+
+func parseODT(source string) (*Document, error) {
+	archive, err := openOfficeArchive(source)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := readArchiveFile(archive, "content.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &odtParser{
+		textStyles: map[string]textStyle{},
+		listStyles: map[string]map[int]bool{},
+	}
+	if styles, readErr := readArchiveFile(archive, "styles.xml"); readErr == nil {
+		if err := parser.parse(styles); err != nil {
+			return nil, err
+		}
+		parser.paragraphs = nil
+	}
+	if err := parser.parse(document); err != nil {
+		return nil, err
+	}
+	return officeDocument(parser.paragraphs), nil
+}
+
+func (p *odtParser) parse(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("parse odt: %w", err)
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			p.start(element)
+		case xml.EndElement:
+			p.end(element)
+		case xml.CharData:
+			if p.current != nil && p.skipDepth == 0 {
+				p.current.appendRun(odtWhitespacePattern.ReplaceAllString(string(element), " "), p.spanStyle())
+			}
+		}
+	}
+}
+
+func (p *odtParser) start(element xml.StartElement) {
+	if p.skipDepth > 0 {
+		p.skipDepth++
+		return
+	}
+
+	switch element.Name.Local {
+	case "annotation", "note", "tracked-changes":
+		p.skipDepth = 1
+	case "style":
+		p.styleName = xmlAttr(element, "name")
+		p.styleFamily = xmlAttr(element, "family")
+		style := p.textStyles[xmlAttr(element, "parent-style-name")]
+		applyNamedCharacterStyle(&style, p.styleName)
+		p.textStyles[p.styleName] = style
+	case "text-properties":
+		if p.styleName == "" {
+			return
+		}
+		style := p.textStyles[p.styleName]
+		if weight := xmlAttr(element, "font-weight"); weight != "" {
+			numeric, err := strconv.Atoi(weight)
+			style.bold = weight == "bold" || (err == nil && numeric >= 600)
+		}
+		if fontStyle := xmlAttr(element, "font-style"); fontStyle != "" {
+			style.italic = fontStyle == "italic" || fontStyle == "oblique"
+		}
+		if font := xmlAttr(element, "font-name"); font != "" {
+			style.monospace = isMonospaceFont(font)
+		}
+		p.textStyles[p.styleName] = style
+	case "list-style":
+		p.styleName = xmlAttr(element, "name")
+		p.listStyles[p.styleName] = map[int]bool{}
+	case "list-level-style-number", "list-level-style-bullet":
+		if levels, ok := p.listStyles[p.styleName]; ok {
+			level, _ := strconv.Atoi(xmlAttr(element, "level"))
+			levels[level-1] = element.Name.Local == "list-level-style-number"
+		}
+	case "list":
+		styleName := xmlAttr(element, "style-name")
+		if styleName == "" && len(p.lists) > 0 {
+			styleName = p.lists[len(p.lists)-1].styleName
+		}
+		p.lists = append(p.lists, odtList{styleName: styleName})
+	case "list-item":
+		if len(p.lists) > 0 {
+			p.lists[len(p.lists)-1].freshItem = true
+		}
+	case "p", "h":
+		p.current = &officeParagraph{}
+		if element.Name.Local == "h" {
+			p.current.heading, _ = strconv.Atoi(xmlAttr(element, "outline-level"))
+			if p.current.heading <= 0 {
+				p.current.heading = 1
+			}
+		}
+		if len(p.lists) > 0 {
+			list := &p.lists[len(p.lists)-1]
+			p.current.listed = true
+			p.current.level = len(p.lists) - 1
+			p.current.ordered = p.listStyles[list.styleName][p.current.level]
+			p.current.continuation = !list.freshItem
+			list.freshItem = false
+		}
+		p.spans = append(p.spans[:0], p.textStyles[xmlAttr(element, "style-name")])
+	case "span", "a":
+		style := p.spanStyle()
+		if named, ok := p.textStyles[xmlAttr(element, "style-name")]; ok {
+			style.bold = style.bold || named.bold
+			style.italic = style.italic || named.italic
+			style.monospace = style.monospace || named.monospace
+		}
+		p.spans = append(p.spans, style)
+	case "s":
+		if p.current != nil {
+			count, err := strconv.Atoi(xmlAttr(element, "c"))
+			if err != nil || count < 1 {
+				count = 1
+			}
+			p.current.appendRun(strings.Repeat(" ", count), p.spanStyle())
+		}
+	case "tab":
+		if p.current != nil {
+			p.current.appendRun(" ", p.spanStyle())
+		}
+	case "line-break":
+		if p.current != nil {
+			p.current.appendRun("\n", p.spanStyle())
+		}
+	}
+}
+
+func (p *odtParser) end(element xml.EndElement) {
+	if p.skipDepth > 0 {
+		p.skipDepth--
+		return
+	}
+
+	switch element.Name.Local {
+	case "style", "list-style":
+		p.styleName = ""
+	case "list":
+		if len(p.lists) > 0 {
+			p.lists = p.lists[:len(p.lists)-1]
+		}
+	case "span", "a":
+		if len(p.spans) > 1 {
+			p.spans = p.spans[:len(p.spans)-1]
+		}
+	case "p", "h":
+		if p.current != nil {
+			p.paragraphs = append(p.paragraphs, *p.current)
+			p.current = nil
+		}
+	}
+}
+
+func (p *odtParser) spanStyle() textStyle {
+	if len(p.spans) == 0 {
+		return textStyle{}
+	}
+	return p.spans[len(p.spans)-1]
+}
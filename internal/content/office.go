@@ -0,0 +1,138 @@
+package content
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const maxArchiveFileSize = 64 << 20
+
+type officeRun struct {
+	text  string
+	style textStyle
+}
+
+type officeParagraph struct {
+	heading      int
+	listed       bool
+	continuation bool
+	ordered      bool
+	level        int
+	runs         []officeRun
+}
+
+func (p *officeParagraph) appendRun(text string, style textStyle) {
+	if text == "" {
+		return
+	}
+	if last := len(p.runs) - 1; last >= 0 && p.runs[last].style == style {
+		p.runs[last].text += text
+		return
+	}
+	p.runs = append(p.runs, officeRun{text: text, style: style})
+}
+
+func (p *officeParagraph) empty() bool {
+	for _, run := range p.runs {
+		if strings.TrimSpace(run.text) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func openOfficeArchive(source string) (*zip.Reader, error) {
+	archive, err := zip.NewReader(bytes.NewReader([]byte(source)), int64(len(source)))
+	if err != nil {
+		return nil, fmt.Errorf("open document archive: %w", err)
+	}
+	return archive, nil
+}
+
+func readArchiveFile(archive *zip.Reader, name string) ([]byte, error) {
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", name, err)
+		}
+		defer reader.Close()
+
+		data, err := io.ReadAll(io.LimitReader(reader, maxArchiveFileSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		if len(data) > maxArchiveFileSize {
+			return nil, fmt.Errorf("%s is larger than %d MiB", name, maxArchiveFileSize>>20)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("document is missing %s", name)
+}
+
+func officeDocument(paragraphs []officeParagraph) *Document {
+	doc := &Document{}
+
+	var lists []*Block
+	for _, paragraph := range paragraphs {
+		if paragraph.empty() {
+			continue
+		}
+		if !paragraph.listed {
+			lists = nil
+			doc.Blocks = append(doc.Blocks, paragraph.block())
+			continue
+		}
+
+		if len(lists) > paragraph.level+1 {
+			lists = lists[:paragraph.level+1]
+		}
+		for len(lists) <= paragraph.level {
+			list := &Block{Kind: BlockList, Ordered: paragraph.ordered, Start: 1}
+			if len(lists) == 0 {
+				doc.Blocks = append(doc.Blocks, list)
+			} else {
+				parent := lists[len(lists)-1]
+				if len(parent.Items) == 0 {
+					parent.Items = append(parent.Items, &ListItem{})
+				}
+				item := parent.Items[len(parent.Items)-1]
+				item.Blocks = append(item.Blocks, list)
+			}
+			lists = append(lists, list)
+		}
+
+		list := lists[paragraph.level]
+		if paragraph.continuation && len(list.Items) > 0 {
+			item := list.Items[len(list.Items)-1]
+			item.Blocks = append(item.Blocks, paragraph.block())
+			continue
+		}
+		list.Items = append(list.Items, &ListItem{Blocks: []*Block{paragraph.block()}})
+	}
+	return doc
+}
+
+func (p *officeParagraph) block() *Block {
+	block := &Block{Kind: BlockParagraph}
+	if p.heading > 0 {
+		block.Kind = BlockHeading
+		block.Level = p.heading
+	}
+	for i, run := range p.runs {
+		text := run.text
+		if i == 0 {
+			text = strings.TrimLeft(text, " ")
+		}
+		if i == len(p.runs)-1 {
+			text = strings.TrimRight(text, " ")
+		}
+		block.appendLines(text, run.style)
+	}
+	return block
+}
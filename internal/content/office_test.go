@@ -0,0 +1,241 @@
+package content
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func zipArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, body := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := file.Write([]byte(body)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close archive: %v", err)
+	}
+	return buffer.String()
+}
+
+func styledTexts(doc *Document) []string {
+	var texts []string
+	doc.Walk(func(block *Block) {
+		for _, inline := range block.Inlines {
+			prefix := ""
+			if inline.Bold {
+				prefix += "b:"
+			}
+			if inline.Italic {
+				prefix += "i:"
+			}
+			texts = append(texts, prefix+inline.Text)
+		}
+	})
+	return texts
+}
+
+func TestParseDOCX(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Opening</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">Good </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>evening</w:t></w:r><w:r><w:rPr><w:i/><w:b w:val="0"/></w:rPr><w:t xml:space="preserve"> everyone</w:t></w:r></w:p>
+<w:p/>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>First</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Second</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Detail</w:t></w:r></w:p>
+</w:body>
+</w:document>`
+	numbering := `<?xml version="1.0" encoding="UTF-8"?>
+<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:abstractNum w:abstractNumId="4">
+<w:lvl w:ilvl="0"><w:numFmt w:val="decimal"/></w:lvl>
+<w:lvl w:ilvl="1"><w:numFmt w:val="bullet"/></w:lvl>
+</w:abstractNum>
+<w:num w:numId="1"><w:abstractNumId w:val="4"/></w:num>
+</w:numbering>`
+
+	doc, err := parseDOCX(zipArchive(t, map[string]string{
+		"word/document.xml":  document,
+		"word/numbering.xml": numbering,
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"Opening", "Good ", "b:evening", "i: everyone", "First", "Second", "Detail"}
+	if got := styledTexts(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	wantText := "Opening\n\nGood evening everyone\n\n1. First\n2. Second\n   - Detail\n"
+	if got := doc.PlainText(); got != wantText {
+		t.Fatalf("expected %q, got %q", wantText, got)
+	}
+}
+
+func TestParseDOCXFollowsStyles(t *testing.T) {
+	styles := `<?xml version="1.0" encoding="UTF-8"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults><w:rPrDefault><w:rPr><w:b/></w:rPr></w:rPrDefault></w:docDefaults>
+<w:style w:type="paragraph" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+<w:style w:type="paragraph" w:styleId="Berschrift2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/></w:style>
+<w:style w:type="paragraph" w:styleId="ChapterTitle"><w:name w:val="Chapter Title"/><w:basedOn w:val="Berschrift2"/></w:style>
+<w:style w:type="paragraph" w:styleId="Act"><w:name w:val="Act"/><w:basedOn w:val="Normal"/><w:pPr><w:outlineLvl w:val="0"/></w:pPr></w:style>
+<w:style w:type="paragraph" w:styleId="Aside"><w:name w:val="Aside"/><w:basedOn w:val="Act"/><w:pPr><w:outlineLvl w:val="9"/></w:pPr><w:rPr><w:i/></w:rPr></w:style>
+<w:style w:type="character" w:styleId="Slanted"><w:name w:val="Slanted"/><w:rPr><w:i/></w:rPr></w:style>
+<w:style w:type="character" w:styleId="Loud"><w:name w:val="Loud"/><w:basedOn w:val="Slanted"/><w:rPr><w:b/></w:rPr></w:style>
+<w:style w:type="character" w:styleId="Quiet"><w:name w:val="Quiet"/><w:basedOn w:val="Loud"/><w:rPr><w:i w:val="0"/></w:rPr></w:style>
+<w:style w:type="character" w:styleId="LoopA"><w:basedOn w:val="LoopB"/></w:style>
+<w:style w:type="character" w:styleId="LoopB"><w:basedOn w:val="LoopA"/><w:rPr><w:b/></w:rPr></w:style>
+<w:style w:type="table" w:styleId="Grid"><w:tblStylePr w:type="firstRow"><w:rPr><w:b/></w:rPr></w:tblStylePr></w:style>
+</w:styles>`
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:pPr><w:pStyle w:val="ChapterTitle"/></w:pPr><w:r><w:t>Chapter</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="Act"/></w:pPr><w:r><w:t>Act one</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="Aside"/></w:pPr><w:r><w:t xml:space="preserve">Aside </w:t></w:r><w:r><w:rPr><w:i w:val="0"/></w:rPr><w:t>plain</w:t></w:r></w:p>
+<w:p><w:pPr><w:outlineLvl w:val="2"/></w:pPr><w:r><w:t>Scene</w:t></w:r></w:p>
+<w:p><w:r><w:rPr><w:rStyle w:val="Loud"/></w:rPr><w:t>loud</w:t></w:r><w:r><w:rPr><w:rStyle w:val="Quiet"/></w:rPr><w:t>quiet</w:t></w:r><w:r><w:rPr><w:rStyle w:val="LoopA"/></w:rPr><w:t>loop</w:t></w:r></w:p>
+</w:body>
+</w:document>`
+
+	doc, err := parseDOCX(zipArchive(t, map[string]string{
+		"word/document.xml": document,
+		"word/styles.xml":   styles,
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"Chapter", "Act one", "i:Aside ", "plain", "Scene", "b:i:loud", "b:quietloop"}
+	if got := styledTexts(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	levels := []int{2, 1, 0, 3, 0}
+	if len(doc.Blocks) != len(levels) {
+		t.Fatalf("expected %d blocks, got %d", len(levels), len(doc.Blocks))
+	}
+	for i, level := range levels {
+		block := doc.Blocks[i]
+		if (level > 0) != (block.Kind == BlockHeading) || (level > 0 && block.Level != level) {
+			t.Fatalf("block %d: expected heading level %d, got %v level %d", i, level, block.Kind, block.Level)
+		}
+	}
+}
+
+func TestParseODT(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content
+  xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+  xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+  xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+  xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0">
+<office:automatic-styles>
+  <style:style style:name="T1" style:family="text"><style:text-properties fo:font-weight="bold"/></style:style>
+  <style:style style:name="T2" style:family="text"><style:text-properties fo:font-style="italic"/></style:style>
+  <text:list-style style:name="L1">
+    <text:list-level-style-bullet text:level="1"/>
+    <text:list-level-style-number text:level="2"/>
+  </text:list-style>
+</office:automatic-styles>
+<office:body><office:text>
+  <text:h text:outline-level="2">Opening</text:h>
+  <text:p>Good <text:span text:style-name="T1">evening</text:span><text:s/><text:span text:style-name="T2">everyone</text:span><office:annotation><text:p>draft</text:p></office:annotation></text:p>
+  <text:list text:style-name="L1">
+    <text:list-item><text:p>First</text:p><text:p>More</text:p>
+      <text:list><text:list-item><text:p>Detail</text:p></text:list-item></text:list>
+    </text:list-item>
+  </text:list>
+</office:text></office:body>
+</office:document-content>`
+
+	doc, err := parseODT(zipArchive(t, map[string]string{"content.xml": document}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"Opening", "Good ", "b:evening", " ", "i:everyone", "First", "More", "Detail"}
+	if got := styledTexts(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	wantText := "Opening\n\nGood evening everyone\n\n- First\n  More\n  1. Detail\n"
+	if got := doc.PlainText(); got != wantText {
+		t.Fatalf("expected %q, got %q", wantText, got)
+	}
+	if heading := doc.Blocks[0]; heading.Kind != BlockHeading || heading.Level != 2 {
+		t.Fatalf("expected a level 2 heading, got %+v", heading)
+	}
+}
+
+func TestParseODTMergesParentStyles(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content
+  xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+  xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+  xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+  xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0">
+<office:styles>
+  <style:style style:name="Slanted" style:family="text"><style:text-properties fo:font-style="italic"/></style:style>
+  <style:style style:name="Strong_20_Emphasis" style:family="text" style:parent-style-name="Slanted"/>
+  <style:style style:name="Loud" style:family="text" style:parent-style-name="Slanted"><style:text-properties fo:font-weight="bold"/></style:style>
+  <style:style style:name="Quiet" style:family="text" style:parent-style-name="Loud"><style:text-properties fo:font-style="normal"/></style:style>
+</office:styles>
+<office:body><office:text>
+  <text:p><text:span text:style-name="Strong_20_Emphasis">named</text:span> <text:span text:style-name="Loud">loud</text:span> <text:span text:style-name="Quiet">quiet</text:span></text:p>
+</office:text></office:body>
+</office:document-content>`
+
+	doc, err := parseODT(zipArchive(t, map[string]string{"content.xml": document}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"b:i:named", " ", "b:i:loud", " ", "b:quiet"}
+	if got := styledTexts(doc); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestReadArchiveFileRejectsOversizedEntries(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	file, err := writer.Create("content.xml")
+	if err != nil {
+		t.Fatalf("create content.xml: %v", err)
+	}
+	chunk := make([]byte, 1<<20)
+	for written := 0; written <= maxArchiveFileSize; written += len(chunk) {
+		if _, err := file.Write(chunk); err != nil {
+			t.Fatalf("write content.xml: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close archive: %v", err)
+	}
+
+	archive, err := openOfficeArchive(buffer.String())
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	if _, err := readArchiveFile(archive, "content.xml"); err == nil {
+		t.Fatal("expected an error for an oversized entry")
+	}
+}
+
+func TestParseOfficeRejectsInvalidArchive(t *testing.T) {
+	if _, err := parseDOCX("not a zip"); err == nil {
+		t.Fatal("expected an error for an invalid archive")
+	}
+}
@@ -3,233 +3,299 @@ package content
 import (
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/widget"
-	"golang.org/x/net/html"
 )
 
-func Render(data []byte, format Format) (fyne.CanvasObject, error) {
+const listIndent = "    "
+
+func Render(data []byte, format Format) (fyne.CanvasObject, []Cue, error) {
 	return RenderWithOptions(data, format, DefaultRenderOptions())
 }
 
-func RenderWithOptions(data []byte, format Format, options RenderOptions) (fyne.CanvasObject, error) {
-	normalizedOptions := options
-	normalizedOptions.WordSpacing = NormalizeWordSpacing(options.WordSpacing)
-
-	switch format {
-	case FormatMarkdown:
-		return renderMarkdown(string(data), normalizedOptions), nil
-	case FormatHTML:
-		return renderHTML(string(data), normalizedOptions)
-	default:
-		return nil, fmt.Errorf("unsupported render format: %s", format)
+func RenderWithOptions(data []byte, format Format, options RenderOptions) (fyne.CanvasObject, []Cue, error) {
+	doc, err := Parse(data, format)
+	if err != nil {
+		return nil, nil, err
 	}
+	object, cues := RenderDocument(doc, options)
+	return object, cues, nil
 }
 
-func renderMarkdown(markdown string, options RenderOptions) *widget.RichText {
-	richText := widget.NewRichTextFromMarkdown(markdown)
-	richText.Wrapping = fyne.TextWrapWord
-	ApplyTypography(richText)
-	ApplyWordSpacing(richText, options.WordSpacing)
-	return richText
-}
+func Parse(data []byte, format Format) (*Document, error) {
+	spec, ok := lookupFormat(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
 
-func renderHTML(rawHTML string, options RenderOptions) (fyne.CanvasObject, error) {
-	doc, err := html.Parse(strings.NewReader(rawHTML))
+	source := string(data)
+	var cues []Cue
+	if !spec.binary {
+		source, cues = extractCues(source, nil)
+	}
+	doc, err := spec.parse(source)
 	if err != nil {
-		return nil, fmt.Errorf("parse html: %w", err)
+		return nil, err
 	}
+	if spec.binary {
+		cues = extractInlineCues(doc)
+	}
+	attachCues(doc, cues)
+	doc.Metadata.Format = format
+	return doc, nil
+}
 
-	ctx := renderContext{segments: make([]widget.RichTextSegment, 0, 32)}
-	renderNode(doc, textStyle{}, &ctx)
+func RenderDocument(doc *Document, options RenderOptions) (fyne.CanvasObject, []Cue) {
+	renderer := &fyneRenderer{}
+	renderer.blocks(doc.Blocks, "", true)
 
-	richText := widget.NewRichText(ctx.segments...)
+	richText := widget.NewRichText(renderer.segments...)
 	richText.Wrapping = fyne.TextWrapWord
 	ApplyTypography(richText)
 	ApplyWordSpacing(richText, options.WordSpacing)
-	return richText, nil
+	return richText, renderer.cues
 }
 
-type textStyle struct {
-	bold      bool
-	italic    bool
-	monospace bool
+type Section struct {
+	Title   string
+	Level   int
+	Segment int
 }
 
-type renderContext struct {
-	segments []widget.RichTextSegment
+func Outline(doc *Document) []Section {
+	renderer := &fyneRenderer{}
+	renderer.blocks(doc.Blocks, "", true)
+	return renderer.sections
 }
 
-func renderNode(node *html.Node, style textStyle, ctx *renderContext) {
-	switch node.Type {
-	case html.TextNode:
-		appendText(ctx, normalizeWhitespace(node.Data), style)
-		return
-	case html.ElementNode:
-		renderElement(node, style, ctx)
-		return
-	}
+type fyneRenderer struct {
+	segments   []widget.RichTextSegment
+	cues       []Cue
+	sections   []Section
+	paragraphs []paragraphSpan
+	length     int
+}
 
-	for child := node.FirstChild; child != nil; child = child.NextSibling {
-		renderNode(child, style, ctx)
+func (r *fyneRenderer) blocks(blocks []*Block, indent string, spaced bool) {
+	for _, block := range blocks {
+		if !visibleBlock(block) {
+			r.skip(block)
+			continue
+		}
+		if spaced && len(r.segments) > 0 {
+			r.endRow(widget.RichTextStyleParagraph)
+		}
+		r.block(block, indent, "")
 	}
 }
 
-func renderElement(node *html.Node, style textStyle, ctx *renderContext) {
-	switch node.Data {
-	case "br":
-		appendRawText(ctx, "\n", style)
-		return
-	case "p", "div", "section", "article":
-		appendRawText(ctx, "\n", style)
-		renderChildren(node, style, ctx)
-		appendRawText(ctx, "\n", style)
-		return
-	case "h1", "h2", "h3":
-		headerStyle := style
-		headerStyle.bold = true
-		appendRawText(ctx, "\n", headerStyle)
-		renderChildren(node, headerStyle, ctx)
-		appendRawText(ctx, "\n", headerStyle)
-		return
-	case "strong", "b":
-		next := style
-		next.bold = true
-		renderChildren(node, next, ctx)
-		return
-	case "em", "i":
-		next := style
-		next.italic = true
-		renderChildren(node, next, ctx)
-		return
-	case "code", "pre":
-		next := style
-		next.monospace = true
-		renderChildren(node, next, ctx)
-		return
-	case "a":
-		appendLink(node, style, ctx)
-		return
-	case "ul":
-		appendRawText(ctx, "\n", style)
-		renderList(node, false, style, ctx)
-		appendRawText(ctx, "\n", style)
-		return
-	case "ol":
-		appendRawText(ctx, "\n", style)
-		renderList(node, true, style, ctx)
-		appendRawText(ctx, "\n", style)
-		return
+func (r *fyneRenderer) block(block *Block, indent, marker string) {
+	if block.Kind != BlockList && block.Kind != BlockQuote {
+		start := r.length
+		defer func() {
+			r.paragraphs = append(r.paragraphs, paragraphSpan{
+				text:  paragraphKey(block),
+				start: start,
+				end:   r.length,
+			})
+		}()
 	}
 
-	renderChildren(node, style, ctx)
-}
-
-func renderChildren(node *html.Node, style textStyle, ctx *renderContext) {
-	for child := node.FirstChild; child != nil; child = child.NextSibling {
-		renderNode(child, style, ctx)
+	continuation := indent + strings.Repeat(" ", utf8.RuneCountInString(marker))
+	switch block.Kind {
+	case BlockHeading:
+		r.sections = append(r.sections, Section{
+			Title:   strings.Join(strings.Fields(plainInlines(block.Inlines, "")), " "),
+			Level:   block.Level,
+			Segment: len(r.segments),
+		})
+		style := headingStyle(block.Level)
+		style.Alignment = textAlign(block.Align)
+		r.inlines(block, indent+marker, continuation, style)
+	case BlockCode:
+		style := widget.RichTextStyleCodeBlock
+		style.Alignment = textAlign(block.Align)
+		r.inlines(block, indent+marker, continuation, style)
+	case BlockRule:
+		r.segments = append(r.segments, &widget.SeparatorSegment{})
+		r.length++
+	case BlockList:
+		r.list(block, indent)
+	case BlockQuote:
+		r.blocks(block.Children, continuation+listIndent, false)
+	default:
+		style := widget.RichTextStyleParagraph
+		style.Alignment = textAlign(block.Align)
+		r.inlines(block, indent+marker, continuation, style)
 	}
 }
 
-func renderList(node *html.Node, ordered bool, style textStyle, ctx *renderContext) {
-	index := 1
-	for child := node.FirstChild; child != nil; child = child.NextSibling {
-		if child.Type != html.ElementNode || child.Data != "li" {
-			continue
+func (r *fyneRenderer) list(block *Block, indent string) {
+	for i, item := range block.Items {
+		marker := "• "
+		if block.Ordered {
+			marker = fmt.Sprintf("%d. ", block.Start+i)
 		}
 
-		prefix := "- "
-		if ordered {
-			prefix = strconv.Itoa(index) + ". "
+		first := true
+		for _, child := range item.Blocks {
+			if !visibleBlock(child) {
+				r.skip(child)
+				continue
+			}
+			switch {
+			case child.Kind == BlockList:
+				r.list(child, indent+listIndent)
+			case first:
+				r.block(child, indent, marker)
+			default:
+				r.block(child, indent+strings.Repeat(" ", utf8.RuneCountInString(marker)), "")
+			}
+			first = false
 		}
-		appendRawText(ctx, prefix, style)
-		renderChildren(child, style, ctx)
-		appendRawText(ctx, "\n", style)
-		index++
 	}
 }
 
-func appendLink(node *html.Node, style textStyle, ctx *renderContext) {
-	var href string
-	for _, attr := range node.Attr {
-		if attr.Key == "href" {
-			href = attr.Val
-			break
+func (r *fyneRenderer) inlines(block *Block, prefix, continuation string, base widget.RichTextStyle) {
+	rowStarted := false
+	startRow := func() {
+		if rowStarted {
+			return
+		}
+		rowStarted = true
+		if prefix != "" {
+			r.text(prefix, inlineStyle(base, Inline{}))
 		}
 	}
 
-	text := strings.TrimSpace(extractText(node))
-	if text == "" {
-		text = href
+	for _, inline := range block.Inlines {
+		switch inline.Kind {
+		case InlineCue:
+			r.cue(inline.Cue)
+		case InlineBreak:
+			startRow()
+			r.endRow(base)
+			prefix = continuation
+			rowStarted = false
+		case InlineLink:
+			startRow()
+			r.link(inline, base)
+		default:
+			startRow()
+			r.text(inline.Text, inlineStyle(base, inline))
+		}
 	}
+	startRow()
+	r.endRow(base)
+}
+
+func (r *fyneRenderer) text(text string, style widget.RichTextStyle) {
 	if text == "" {
 		return
 	}
+	r.segments = append(r.segments, &widget.TextSegment{Text: text, Style: style})
+	r.length += utf8.RuneCountInString(text)
+}
 
-	if href == "" {
-		appendText(ctx, text, style)
+func (r *fyneRenderer) link(inline Inline, base widget.RichTextStyle) {
+	parsed, err := url.Parse(inline.URL)
+	if inline.URL == "" || err != nil {
+		r.text(inline.Text, inlineStyle(base, inline))
 		return
 	}
+	r.segments = append(r.segments, &widget.HyperlinkSegment{
+		Alignment: base.Alignment,
+		Text:      inline.Text,
+		URL:       parsed,
+	})
+	r.length += utf8.RuneCountInString(inline.Text)
+}
 
-	parsedURL, err := url.Parse(href)
-	if err != nil {
-		appendText(ctx, text, style)
-		return
-	}
+func (r *fyneRenderer) endRow(style widget.RichTextStyle) {
+	style.Inline = false
+	r.segments = append(r.segments, &widget.TextSegment{Style: style})
+	r.length++
+}
+
+func (r *fyneRenderer) cue(cue Cue) {
+	cue.Segment = len(r.segments)
+	r.cues = append(r.cues, cue)
+}
 
-	ctx.segments = append(ctx.segments, &widget.HyperlinkSegment{
-		Text: text,
-		URL:  parsedURL,
+func (r *fyneRenderer) skip(block *Block) {
+	walkBlocks([]*Block{block}, func(nested *Block) {
+		for _, inline := range nested.Inlines {
+			if inline.Kind == InlineCue {
+				r.cue(inline.Cue)
+			}
+		}
 	})
 }
 
-func appendText(ctx *renderContext, text string, style textStyle) {
-	if text == "" {
-		return
+func visibleBlock(block *Block) bool {
+	switch block.Kind {
+	case BlockRule:
+		return true
+	case BlockList:
+		for _, item := range block.Items {
+			for _, child := range item.Blocks {
+				if visibleBlock(child) {
+					return true
+				}
+			}
+		}
+		return false
+	case BlockQuote:
+		for _, child := range block.Children {
+			if visibleBlock(child) {
+				return true
+			}
+		}
+		return false
+	default:
+		return block.hasVisibleText()
 	}
-	appendRawText(ctx, text, style)
 }
 
-func appendRawText(ctx *renderContext, text string, style textStyle) {
-	if text == "" {
-		return
+func headingStyle(level int) widget.RichTextStyle {
+	switch level {
+	case 1:
+		style := widget.RichTextStyleHeading
+		style.SizeName = ThemeSizeContentHeading
+		return style
+	case 2, 3:
+		style := widget.RichTextStyleSubHeading
+		style.SizeName = ThemeSizeContentSubheading
+		return style
+	default:
+		style := widget.RichTextStyleParagraph
+		style.TextStyle.Bold = true
+		style.TextStyle.Italic = level >= 5
+		return style
 	}
-
-	ctx.segments = append(ctx.segments, &widget.TextSegment{
-		Text: text,
-		Style: widget.RichTextStyle{
-			Inline:   true,
-			SizeName: ThemeSizeContentBody,
-			TextStyle: fyne.TextStyle{
-				Bold:      style.bold,
-				Italic:    style.italic,
-				Monospace: style.monospace,
-			},
-		},
-	})
 }
 
-func normalizeWhitespace(value string) string {
-	if strings.TrimSpace(value) == "" {
-		return ""
+func inlineStyle(base widget.RichTextStyle, inline Inline) widget.RichTextStyle {
+	style := base
+	style.Inline = true
+	style.TextStyle = fyne.TextStyle{
+		Bold:      base.TextStyle.Bold || inline.Bold,
+		Italic:    base.TextStyle.Italic || inline.Italic,
+		Monospace: base.TextStyle.Monospace || inline.Monospace,
 	}
-	return strings.Join(strings.Fields(value), " ")
+	return style
 }
 
-func extractText(node *html.Node) string {
-	var b strings.Builder
-	var walk func(*html.Node)
-	walk = func(n *html.Node) {
-		if n.Type == html.TextNode {
-			b.WriteString(normalizeWhitespace(n.Data))
-		}
-		for child := n.FirstChild; child != nil; child = child.NextSibling {
-			walk(child)
-		}
+func textAlign(alignment Alignment) fyne.TextAlign {
+	switch alignment {
+	case AlignCenter:
+		return fyne.TextAlignCenter
+	case AlignTrailing:
+		return fyne.TextAlignTrailing
+	default:
+		return fyne.TextAlignLeading
 	}
-	walk(node)
-	return b.String()
 }
@@ -9,8 +9,15 @@ import (
 
 const (
 	defaultWordSpacing = 1
-	minWordSpacing     = 1
-	maxWordSpacing     = 8
+	MinWordSpacing     = 1
+	MaxWordSpacing     = 8
+)
+
+const (
+	DefaultLineHeight float32 = 1
+	MinLineHeight     float32 = 1
+	MaxLineHeight     float32 = 2.5
+	LineHeightStep    float32 = 0.1
 )
 
 type RenderOptions struct {
@@ -24,11 +31,21 @@ func DefaultRenderOptions() RenderOptions {
 }
 
 func NormalizeWordSpacing(value int) int {
-	if value < minWordSpacing {
-		return minWordSpacing
+	if value < MinWordSpacing {
+		return MinWordSpacing
+	}
+	if value > MaxWordSpacing {
+		return MaxWordSpacing
+	}
+	return value
+}
+
+func NormalizeLineHeight(value float32) float32 {
+	if value < MinLineHeight {
+		return MinLineHeight
 	}
-	if value > maxWordSpacing {
-		return maxWordSpacing
+	if value > MaxLineHeight {
+		return MaxLineHeight
 	}
 	return value
 }
@@ -0,0 +1,155 @@
+package content
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const subtitleParagraphGap = 2 * time.Second
+
+var (
+	subtitleTimingPattern = regexp.MustCompile(`^\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{3})\s*-->\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{3})`)
+	subtitleVoicePattern  = regexp.MustCompile(`<v(?:\.[^\s>]+)?\s+([^>]+)>`)
+	subtitleTagPattern    = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+)
+
+type subtitleCue struct {
+	start   time.Duration
+	end     time.Duration
+	speaker string
+	text    string
+}
+
+func parseSubtitles(source string) (*Document, error) {
+	cues := parseSubtitleCues(source)
+
+	doc := &Document{}
+	var paragraph []string
+	speaker := ""
+	var previous *subtitleCue
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		block := &Block{Kind: BlockParagraph}
+		if speaker != "" {
+			block.appendInline(textStyle{bold: true}.text(speaker + ": "))
+		}
+		block.appendInline(textStyle{}.text(strings.Join(paragraph, " ")))
+		doc.Blocks = append(doc.Blocks, block)
+		paragraph = nil
+	}
+
+	for i := range cues {
+		cue := &cues[i]
+		if previous != nil && cue.text == previous.text {
+			previous = cue
+			continue
+		}
+		if previous != nil {
+			paused := cue.start-previous.end >= subtitleParagraphGap
+			changedSpeaker := cue.speaker != "" && cue.speaker != speaker
+			if paused || changedSpeaker {
+				flush()
+				speaker = ""
+			}
+		}
+		if cue.speaker != "" {
+			speaker = cue.speaker
+		}
+		paragraph = append(paragraph, cue.text)
+		previous = cue
+	}
+	flush()
+
+	return doc, nil
+}
+
+func splitSubtitleBlocks(source string) [][]string {
+	var blocks [][]string
+	var block []string
+	for _, line := range strings.Split(normalizeNewlines(source), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func parseSubtitleCues(source string) []subtitleCue {
+	vtt := strings.HasPrefix(strings.TrimPrefix(source, "\ufeff"), "WEBVTT")
+	blocks := splitSubtitleBlocks(source)
+
+	cues := make([]subtitleCue, 0, len(blocks))
+	for _, lines := range blocks {
+		timingIndex := -1
+		for i, line := range lines {
+			if subtitleTimingPattern.MatchString(line) {
+				timingIndex = i
+				break
+			}
+		}
+		if timingIndex < 0 {
+			continue
+		}
+
+		timing := subtitleTimingPattern.FindStringSubmatch(lines[timingIndex])
+		cue := subtitleCue{
+			start: parseSubtitleTimestamp(timing[1]),
+			end:   parseSubtitleTimestamp(timing[2]),
+		}
+
+		textLines := make([]string, 0, len(lines)-timingIndex-1)
+		for _, line := range lines[timingIndex+1:] {
+			if voice := subtitleVoicePattern.FindStringSubmatch(line); voice != nil && cue.speaker == "" {
+				cue.speaker = strings.TrimSpace(voice[1])
+				if vtt {
+					cue.speaker = html.UnescapeString(cue.speaker)
+				}
+			}
+			text := subtitleTagPattern.ReplaceAllString(line, "")
+			if vtt {
+				text = html.UnescapeString(text)
+			}
+			text = strings.TrimSpace(text)
+			if text != "" {
+				textLines = append(textLines, text)
+			}
+		}
+		cue.text = strings.Join(textLines, " ")
+		if cue.text != "" {
+			cues = append(cues, cue)
+		}
+	}
+	return cues
+}
+
+func parseSubtitleTimestamp(value string) time.Duration {
+	parts := strings.Split(strings.Replace(value, ",", ".", 1), ":")
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0
+	}
+	multiplier := 60.0
+	for i := len(parts) - 2; i >= 0; i-- {
+		parsed, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0
+		}
+		seconds += float64(parsed) * multiplier
+		multiplier *= 60
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
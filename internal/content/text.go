@@ -0,0 +1,42 @@
+package content
+
+import "strings"
+
+func parsePlainText(source string) (*Document, error) {
+	doc := &Document{}
+	for _, paragraph := range splitParagraphs(source) {
+		block := &Block{Kind: BlockParagraph}
+		block.appendLines(paragraph, textStyle{})
+		doc.Blocks = append(doc.Blocks, block)
+	}
+	return doc, nil
+}
+
+func splitParagraphs(source string) []string {
+	lines := strings.Split(normalizeNewlines(source), "\n")
+
+	var paragraphs []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, " \t")
+		if strings.TrimSpace(trimmed) == "" {
+			flush()
+			continue
+		}
+		current = append(current, trimmed)
+	}
+	flush()
+	return paragraphs
+}
+
+func normalizeNewlines(source string) string {
+	source = strings.TrimPrefix(source, "\ufeff")
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	return strings.ReplaceAll(source, "\r", "\n")
+}
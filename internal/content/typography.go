@@ -12,6 +12,12 @@ const (
 	ThemeSizeContentSubheading fyne.ThemeSizeName = "grompt.content.subheading"
 )
 
+const ContentTabWidth = 4
+
+func IsContentStyle(style fyne.TextStyle) bool {
+	return style.TabWidth == ContentTabWidth
+}
+
 func ApplyTypography(object fyne.CanvasObject) {
 	richText, ok := object.(*widget.RichText)
 	if !ok {
@@ -27,11 +33,13 @@ func ApplyTypography(object fyne.CanvasObject) {
 func applySegmentTypography(segment widget.RichTextSegment) {
 	switch current := segment.(type) {
 	case *widget.TextSegment:
+		current.Style.TextStyle.TabWidth = ContentTabWidth
 		switch current.Style.SizeName {
 		case theme.SizeNameHeadingText:
 			current.Style.SizeName = ThemeSizeContentHeading
 		case theme.SizeNameSubHeadingText:
 			current.Style.SizeName = ThemeSizeContentSubheading
+		case ThemeSizeContentHeading, ThemeSizeContentSubheading:
 		default:
 			current.Style.SizeName = ThemeSizeContentBody
 		}
@@ -0,0 +1,95 @@
+package content
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const watchSettle = 200 * time.Millisecond
+
+type FileWatcher struct {
+	watcher *fsnotify.Watcher
+	path    string
+	onEvent func()
+
+	mu    sync.Mutex
+	timer *time.Timer
+	done  chan struct{}
+	once  sync.Once
+}
+
+func WatchFile(path string, onChange func()) (*FileWatcher, error) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(absolute)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	w := &FileWatcher{
+		watcher: watcher,
+		path:    absolute,
+		onEvent: onChange,
+		done:    make(chan struct{}),
+	}
+	go w.loop()
+	return w, nil
+}
+
+func (w *FileWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		err = w.watcher.Close()
+		<-w.done
+
+		w.mu.Lock()
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+		w.mu.Unlock()
+	})
+	return err
+}
+
+func (w *FileWatcher) loop() {
+	defer close(w.done)
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.path {
+				continue
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+				w.schedule()
+			}
+		case _, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (w *FileWatcher) schedule() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(watchSettle, w.onEvent)
+}
@@ -0,0 +1,302 @@
+package input
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+type Action string
+
+const (
+	ActionPlayPause         Action = "play_pause"
+	ActionSpeedUp           Action = "speed_up"
+	ActionSpeedDown         Action = "speed_down"
+	ActionFontSizeUp        Action = "font_size_up"
+	ActionFontSizeDown      Action = "font_size_down"
+	ActionWordSpacingUp     Action = "word_spacing_up"
+	ActionWordSpacingDown   Action = "word_spacing_down"
+	ActionLineHeightUp      Action = "line_height_up"
+	ActionLineHeightDown    Action = "line_height_down"
+	ActionLetterSpacingUp   Action = "letter_spacing_up"
+	ActionLetterSpacingDown Action = "letter_spacing_down"
+	ActionMarginUp          Action = "margin_up"
+	ActionMarginDown        Action = "margin_down"
+	ActionRewind            Action = "rewind"
+	ActionResetToTop        Action = "reset_to_top"
+	ActionNextSection       Action = "next_section"
+	ActionPreviousSection   Action = "previous_section"
+	ActionToggleReverse     Action = "reverse"
+	ActionToggleMirror      Action = "mirror"
+	ActionToggleFlip        Action = "flip"
+	ActionToggleTalent      Action = "talent_window"
+	ActionFullScreen        Action = "fullscreen"
+	ActionShowHelp          Action = "help"
+)
+
+var KnownActions = []Action{
+	ActionPlayPause,
+	ActionSpeedUp,
+	ActionSpeedDown,
+	ActionFontSizeUp,
+	ActionFontSizeDown,
+	ActionWordSpacingUp,
+	ActionWordSpacingDown,
+	ActionLineHeightUp,
+	ActionLineHeightDown,
+	ActionLetterSpacingUp,
+	ActionLetterSpacingDown,
+	ActionMarginUp,
+	ActionMarginDown,
+	ActionRewind,
+	ActionResetToTop,
+	ActionNextSection,
+	ActionPreviousSection,
+	ActionToggleReverse,
+	ActionToggleMirror,
+	ActionToggleFlip,
+	ActionToggleTalent,
+	ActionFullScreen,
+	ActionShowHelp,
+}
+
+var defaultBindings = map[Action][]Binding{
+	ActionPlayPause:         {{Key: fyne.KeySpace}},
+	ActionSpeedUp:           {{Key: fyne.KeyUp}},
+	ActionSpeedDown:         {{Key: fyne.KeyDown}},
+	ActionFontSizeUp:        {{Key: fyne.KeyPlus}, {Key: fyne.KeyEqual}},
+	ActionFontSizeDown:      {{Key: fyne.KeyMinus}},
+	ActionWordSpacingUp:     {{Key: fyne.KeyRightBracket}},
+	ActionWordSpacingDown:   {{Key: fyne.KeyLeftBracket}},
+	ActionLineHeightUp:      {{Key: fyne.KeyPeriod}},
+	ActionLineHeightDown:    {{Key: fyne.KeyComma}},
+	ActionLetterSpacingUp:   {{Key: fyne.KeyRightBracket, Modifier: fyne.KeyModifierControl}},
+	ActionLetterSpacingDown: {{Key: fyne.KeyLeftBracket, Modifier: fyne.KeyModifierControl}},
+	ActionMarginUp:          {{Key: fyne.KeyPeriod, Modifier: fyne.KeyModifierControl}},
+	ActionMarginDown:        {{Key: fyne.KeyComma, Modifier: fyne.KeyModifierControl}},
+	ActionRewind:            {{Key: fyne.KeyLeft}},
+	ActionResetToTop:        {{Key: fyne.KeyHome}},
+	ActionNextSection:       {{Key: fyne.KeyPageDown}},
+	ActionPreviousSection:   {{Key: fyne.KeyPageUp}},
+	ActionToggleReverse:     {{Key: fyne.KeyR}},
+	ActionToggleMirror:      {{Key: fyne.KeyM}},
+	ActionToggleFlip:        {{Key: fyne.KeyF}},
+	ActionToggleTalent:      {{Key: fyne.KeyT}},
+	ActionFullScreen:        {{Key: fyne.KeyF11}},
+	ActionShowHelp:          {{Key: fyne.KeyF1}},
+}
+
+var keyAliases = map[string]fyne.KeyName{
+	"pageup":    fyne.KeyPageUp,
+	"pgup":      fyne.KeyPageUp,
+	"pagedown":  fyne.KeyPageDown,
+	"pgdn":      fyne.KeyPageDown,
+	"esc":       fyne.KeyEscape,
+	"enter":     fyne.KeyReturn,
+	"backspace": fyne.KeyBackspace,
+	"del":       fyne.KeyDelete,
+	"ins":       fyne.KeyInsert,
+	"plus":      fyne.KeyPlus,
+	"minus":     fyne.KeyMinus,
+	"equal":     fyne.KeyEqual,
+	"comma":     fyne.KeyComma,
+	"period":    fyne.KeyPeriod,
+}
+
+var modifierNames = map[string]fyne.KeyModifier{
+	"shift":   fyne.KeyModifierShift,
+	"ctrl":    fyne.KeyModifierControl,
+	"control": fyne.KeyModifierControl,
+	"alt":     fyne.KeyModifierAlt,
+	"super":   fyne.KeyModifierSuper,
+	"cmd":     fyne.KeyModifierSuper,
+	"meta":    fyne.KeyModifierSuper,
+}
+
+type Binding struct {
+	Key      fyne.KeyName
+	Modifier fyne.KeyModifier
+}
+
+func (b Binding) String() string {
+	var parts []string
+	if b.Modifier&fyne.KeyModifierControl != 0 {
+		parts = append(parts, "Ctrl")
+	}
+	if b.Modifier&fyne.KeyModifierAlt != 0 {
+		parts = append(parts, "Alt")
+	}
+	if b.Modifier&fyne.KeyModifierShift != 0 {
+		parts = append(parts, "Shift")
+	}
+	if b.Modifier&fyne.KeyModifierSuper != 0 {
+		parts = append(parts, "Super")
+	}
+
+	key := string(b.Key)
+	switch b.Key {
+	case fyne.KeyPageUp:
+		key = "PageUp"
+	case fyne.KeyPageDown:
+		key = "PageDown"
+	}
+	return strings.Join(append(parts, key), "+")
+}
+
+func ParseBinding(value string) (Binding, error) {
+	value = strings.TrimSpace(value)
+	keyPart := value
+	modifierPart := ""
+	switch {
+	case value == "+":
+	case strings.HasSuffix(value, "++"):
+		keyPart = "+"
+		modifierPart = strings.TrimSuffix(value, "++")
+	case strings.Contains(value, "+"):
+		index := strings.LastIndex(value, "+")
+		keyPart = value[index+1:]
+		modifierPart = value[:index]
+	}
+
+	var binding Binding
+	if modifierPart != "" {
+		for _, name := range strings.Split(modifierPart, "+") {
+			modifier, ok := modifierNames[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return Binding{}, fmt.Errorf("unknown modifier %q in %q", name, value)
+			}
+			binding.Modifier |= modifier
+		}
+		if binding.Modifier == fyne.KeyModifierShift {
+			return Binding{}, fmt.Errorf("Shift needs Ctrl, Alt or Super as well in %q", value)
+		}
+	}
+
+	key, ok := lookupKey(strings.TrimSpace(keyPart))
+	if !ok {
+		return Binding{}, fmt.Errorf("unknown key %q", value)
+	}
+	binding.Key = key
+	return binding, nil
+}
+
+func lookupKey(name string) (fyne.KeyName, bool) {
+	if name == "" {
+		return "", false
+	}
+	lower := strings.ToLower(name)
+	if key, ok := keyAliases[lower]; ok {
+		return key, true
+	}
+	for _, key := range knownKeys {
+		if strings.ToLower(string(key)) == lower {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+var knownKeys = []fyne.KeyName{
+	fyne.KeyEscape, fyne.KeyReturn, fyne.KeyTab, fyne.KeyBackspace, fyne.KeyInsert, fyne.KeyDelete,
+	fyne.KeyRight, fyne.KeyLeft, fyne.KeyDown, fyne.KeyUp, fyne.KeyPageUp, fyne.KeyPageDown,
+	fyne.KeyHome, fyne.KeyEnd, fyne.KeyEnter, fyne.KeySpace,
+	fyne.KeyF1, fyne.KeyF2, fyne.KeyF3, fyne.KeyF4, fyne.KeyF5, fyne.KeyF6,
+	fyne.KeyF7, fyne.KeyF8, fyne.KeyF9, fyne.KeyF10, fyne.KeyF11, fyne.KeyF12,
+	fyne.Key0, fyne.Key1, fyne.Key2, fyne.Key3, fyne.Key4, fyne.Key5, fyne.Key6, fyne.Key7, fyne.Key8, fyne.Key9,
+	fyne.KeyA, fyne.KeyB, fyne.KeyC, fyne.KeyD, fyne.KeyE, fyne.KeyF, fyne.KeyG, fyne.KeyH, fyne.KeyI,
+	fyne.KeyJ, fyne.KeyK, fyne.KeyL, fyne.KeyM, fyne.KeyN, fyne.KeyO, fyne.KeyP, fyne.KeyQ, fyne.KeyR,
+	fyne.KeyS, fyne.KeyT, fyne.KeyU, fyne.KeyV, fyne.KeyW, fyne.KeyX, fyne.KeyY, fyne.KeyZ,
+	fyne.KeyApostrophe, fyne.KeyComma, fyne.KeyMinus, fyne.KeyPeriod, fyne.KeySlash, fyne.KeyBackslash,
+	fyne.KeyLeftBracket, fyne.KeyRightBracket, fyne.KeySemicolon, fyne.KeyEqual, fyne.KeyAsterisk,
+	fyne.KeyPlus, fyne.KeyBackTick,
+}
+
+type Keymap map[Binding]Action
+
+func DefaultKeymap() Keymap {
+	keymap := Keymap{}
+	for action, bindings := range defaultBindings {
+		for _, binding := range bindings {
+			keymap[binding] = action
+		}
+	}
+	return keymap
+}
+
+func BuildKeymap(overrides map[string]string) (Keymap, []string) {
+	var warnings []string
+
+	custom := map[Action][]Binding{}
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		action := Action(strings.ToLower(name))
+		if _, ok := defaultBindings[action]; !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown keymap action %q ignored", name))
+			continue
+		}
+
+		bindings := []Binding{}
+		for _, value := range strings.Split(overrides[name], ",") {
+			if strings.EqualFold(strings.TrimSpace(value), "none") {
+				continue
+			}
+			binding, err := ParseBinding(value)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("keymap %s: %v", action, err))
+				continue
+			}
+			bindings = append(bindings, binding)
+		}
+		custom[action] = bindings
+	}
+
+	keymap := Keymap{}
+	for _, action := range KnownActions {
+		if _, ok := custom[action]; ok {
+			continue
+		}
+		for _, binding := range defaultBindings[action] {
+			keymap[binding] = action
+		}
+	}
+	for _, action := range KnownActions {
+		bindings, ok := custom[action]
+		if !ok {
+			continue
+		}
+		for _, binding := range bindings {
+			previous, taken := keymap[binding]
+			if !taken || previous == action {
+				keymap[binding] = action
+				continue
+			}
+			if _, previousCustom := custom[previous]; previousCustom {
+				warnings = append(warnings, fmt.Sprintf("keymap conflict: %s is bound to both %s and %s, keeping %s", binding, previous, action, previous))
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("keymap conflict: %s moved from %s to %s", binding, previous, action))
+			keymap[binding] = action
+		}
+	}
+	return keymap, warnings
+}
+
+func (k Keymap) Bindings(action Action) []Binding {
+	var bindings []Binding
+	for binding, bound := range k {
+		if bound == action {
+			bindings = append(bindings, binding)
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].String() < bindings[j].String()
+	})
+	return bindings
+}
@@ -0,0 +1,79 @@
+package input
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestParseBinding(t *testing.T) {
+	cases := map[string]Binding{
+		"Space":        {Key: fyne.KeySpace},
+		"pagedown":     {Key: fyne.KeyPageDown},
+		"b":            {Key: fyne.KeyB},
+		"Ctrl+Shift+T": {Key: fyne.KeyT, Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift},
+		"+":            {Key: fyne.KeyPlus},
+		"Ctrl++":       {Key: fyne.KeyPlus, Modifier: fyne.KeyModifierControl},
+	}
+	for value, want := range cases {
+		got, err := ParseBinding(value)
+		if err != nil {
+			t.Fatalf("parse %q: %v", value, err)
+		}
+		if got != want {
+			t.Fatalf("parse %q: expected %+v, got %+v", value, want, got)
+		}
+	}
+
+	for _, value := range []string{"", "Hyper+A", "Ctrl+Nope", "Shift+]", "shift+A"} {
+		if _, err := ParseBinding(value); err == nil {
+			t.Fatalf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestBuildKeymap(t *testing.T) {
+	keymap, warnings := BuildKeymap(map[string]string{
+		"next_section": "PageDown, B",
+		"play_pause":   "F5",
+		"rewind":       "Space",
+		"speed_up":     "F5",
+		"flip":         "M",
+		"teleport":     "X",
+	})
+
+	if got := keymap.Bindings(ActionNextSection); !reflect.DeepEqual(got, []Binding{{Key: fyne.KeyB}, {Key: fyne.KeyPageDown}}) {
+		t.Fatalf("unexpected next_section bindings %v", got)
+	}
+	if keymap[Binding{Key: fyne.KeySpace}] != ActionRewind {
+		t.Fatal("expected Space to move to rewind")
+	}
+	if keymap[Binding{Key: fyne.KeyLeft}] != "" {
+		t.Fatal("expected the default rewind binding to be replaced")
+	}
+	if keymap[Binding{Key: fyne.KeyF5}] != ActionPlayPause {
+		t.Fatal("expected the first custom F5 binding to win")
+	}
+	if len(keymap.Bindings(ActionToggleMirror)) != 0 || len(keymap.Bindings(ActionSpeedUp)) != 0 {
+		t.Fatal("expected mirror and speed_up to be left unbound")
+	}
+
+	joined := strings.Join(warnings, "\n")
+	for _, want := range []string{`"teleport"`, "M moved from mirror to flip", "F5 is bound to both play_pause and speed_up"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected a warning containing %q, got %q", want, joined)
+		}
+	}
+}
+
+func TestDefaultBindingsAvoidShiftOnly(t *testing.T) {
+	for action, bindings := range defaultBindings {
+		for _, binding := range bindings {
+			if binding.Modifier == fyne.KeyModifierShift {
+				t.Fatalf("%s is bound to %s, which never reaches shortcuts or typed keys", action, binding)
+			}
+		}
+	}
+}
@@ -1,38 +1,119 @@
 package input
 
-import "fyne.io/fyne/v2"
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
 
 type KeyActions struct {
-	OnTogglePlayPause func()
-	OnSpeedUp         func()
-	OnSpeedDown       func()
-	OnFontSizeUp      func()
-	OnFontSizeDown    func()
+	OnTogglePlayPause   func()
+	OnSpeedUp           func()
+	OnSpeedDown         func()
+	OnFontSizeUp        func()
+	OnFontSizeDown      func()
+	OnWordSpacingUp     func()
+	OnWordSpacingDown   func()
+	OnLineHeightUp      func()
+	OnLineHeightDown    func()
+	OnLetterSpacingUp   func()
+	OnLetterSpacingDown func()
+	OnMarginUp          func()
+	OnMarginDown        func()
+	OnRewind            func()
+	OnResetToTop        func()
+	OnNextSection       func()
+	OnPreviousSection   func()
+	OnToggleReverse     func()
+	OnToggleMirror      func()
+	OnToggleFlip        func()
+	OnToggleTalent      func()
+	OnToggleFullScreen  func()
+	OnShowHelp          func()
 }
 
-func BindTeleprompterKeys(canvas fyne.Canvas, actions KeyActions) {
+func (a KeyActions) handler(action Action) func() {
+	switch action {
+	case ActionPlayPause:
+		return a.OnTogglePlayPause
+	case ActionSpeedUp:
+		return a.OnSpeedUp
+	case ActionSpeedDown:
+		return a.OnSpeedDown
+	case ActionFontSizeUp:
+		return a.OnFontSizeUp
+	case ActionFontSizeDown:
+		return a.OnFontSizeDown
+	case ActionWordSpacingUp:
+		return a.OnWordSpacingUp
+	case ActionWordSpacingDown:
+		return a.OnWordSpacingDown
+	case ActionLineHeightUp:
+		return a.OnLineHeightUp
+	case ActionLineHeightDown:
+		return a.OnLineHeightDown
+	case ActionLetterSpacingUp:
+		return a.OnLetterSpacingUp
+	case ActionLetterSpacingDown:
+		return a.OnLetterSpacingDown
+	case ActionMarginUp:
+		return a.OnMarginUp
+	case ActionMarginDown:
+		return a.OnMarginDown
+	case ActionRewind:
+		return a.OnRewind
+	case ActionResetToTop:
+		return a.OnResetToTop
+	case ActionNextSection:
+		return a.OnNextSection
+	case ActionPreviousSection:
+		return a.OnPreviousSection
+	case ActionToggleReverse:
+		return a.OnToggleReverse
+	case ActionToggleMirror:
+		return a.OnToggleMirror
+	case ActionToggleFlip:
+		return a.OnToggleFlip
+	case ActionToggleTalent:
+		return a.OnToggleTalent
+	case ActionFullScreen:
+		return a.OnToggleFullScreen
+	case ActionShowHelp:
+		return a.OnShowHelp
+	default:
+		return nil
+	}
+}
+
+func BindTeleprompterKeys(canvas fyne.Canvas, keymap Keymap, actions KeyActions) {
+	run := func(action Action) {
+		if handler := actions.handler(action); handler != nil {
+			handler()
+		}
+	}
+
+	for binding, action := range keymap {
+		if binding.Modifier == 0 {
+			continue
+		}
+		action := action
+		canvas.AddShortcut(&desktop.CustomShortcut{KeyName: binding.Key, Modifier: binding.Modifier}, func(fyne.Shortcut) {
+			run(action)
+		})
+	}
+
 	canvas.SetOnTypedKey(func(event *fyne.KeyEvent) {
-		switch event.Name {
-		case fyne.KeySpace:
-			if actions.OnTogglePlayPause != nil {
-				actions.OnTogglePlayPause()
-			}
-		case fyne.KeyUp:
-			if actions.OnSpeedUp != nil {
-				actions.OnSpeedUp()
-			}
-		case fyne.KeyDown:
-			if actions.OnSpeedDown != nil {
-				actions.OnSpeedDown()
-			}
-		case fyne.KeyPlus, fyne.KeyEqual:
-			if actions.OnFontSizeUp != nil {
-				actions.OnFontSizeUp()
-			}
-		case fyne.KeyMinus:
-			if actions.OnFontSizeDown != nil {
-				actions.OnFontSizeDown()
-			}
+		if action, ok := keymap[Binding{Key: event.Name}]; ok {
+			run(action)
 		}
 	})
 }
+
+func UnbindTeleprompterKeys(canvas fyne.Canvas, keymap Keymap) {
+	for binding := range keymap {
+		if binding.Modifier == 0 {
+			continue
+		}
+		canvas.RemoveShortcut(&desktop.CustomShortcut{KeyName: binding.Key, Modifier: binding.Modifier})
+	}
+	canvas.SetOnTypedKey(nil)
+}
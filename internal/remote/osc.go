@@ -0,0 +1,430 @@
+package remote
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	oscPrefix    = "/grompt/"
+	oscBundleTag = "#bundle"
+	oscMaxPacket = 65507
+)
+
+var errMalformedOSC = errors.New("malformed OSC packet")
+
+type OSCMessage struct {
+	Address string
+	Args    []any
+}
+
+type OSCServer struct {
+	actions    Actions
+	feedback   *net.UDPAddr
+	scriptsDir string
+
+	mu    sync.Mutex
+	state State
+	sent  *State
+
+	conn   *net.UDPConn
+	stopCh chan struct{}
+	doneCh chan struct{}
+	once   sync.Once
+}
+
+func NewOSCServer(actions Actions, feedbackAddr, scriptsDir string) (*OSCServer, error) {
+	server := &OSCServer{
+		actions:    actions,
+		scriptsDir: scriptsDir,
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+	if feedbackAddr != "" {
+		addr, err := net.ResolveUDPAddr("udp", feedbackAddr)
+		if err != nil {
+			return nil, fmt.Errorf("resolve OSC feedback address: %w", err)
+		}
+		server.feedback = addr
+	}
+	return server, nil
+}
+
+func (s *OSCServer) Start(addr string) error {
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		addr = net.JoinHostPort("127.0.0.1", port)
+	}
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return fmt.Errorf("start OSC listener: %w", err)
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return fmt.Errorf("start OSC listener: %w", err)
+	}
+
+	s.conn = conn
+	go s.read()
+	go s.loop()
+	return nil
+}
+
+func (s *OSCServer) Addr() string {
+	if s.conn == nil {
+		return ""
+	}
+	return s.conn.LocalAddr().String()
+}
+
+func (s *OSCServer) Publish(state State) {
+	s.mu.Lock()
+	s.state = state
+	s.mu.Unlock()
+}
+
+func (s *OSCServer) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.stopCh)
+		if s.conn != nil {
+			err = s.conn.Close()
+			<-s.doneCh
+		}
+	})
+	return err
+}
+
+func (s *OSCServer) read() {
+	buffer := make([]byte, oscMaxPacket)
+	for {
+		n, _, err := s.conn.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+
+		messages, err := DecodeOSC(buffer[:n])
+		if err != nil {
+			continue
+		}
+		for _, message := range messages {
+			_ = s.Dispatch(message)
+		}
+	}
+}
+
+func (s *OSCServer) Dispatch(message OSCMessage) error {
+	if !strings.HasPrefix(message.Address, oscPrefix) {
+		return fmt.Errorf("%w: %q", ErrUnknownAction, message.Address)
+	}
+	address := strings.TrimPrefix(message.Address, oscPrefix)
+
+	trigger := func(action string) error {
+		if value, ok := oscNumber(message.Args, 0); ok && value == 0 {
+			return nil
+		}
+		return dispatch(s.actions, Command{Action: action})
+	}
+
+	switch address {
+	case "play", "pause", "toggle", "fullscreen":
+		return trigger(address)
+	case "speed/up":
+		return trigger("speed_up")
+	case "speed/down":
+		return trigger("speed_down")
+	case "font/up":
+		return trigger("font_size_up")
+	case "font/down":
+		return trigger("font_size_down")
+	case "spacing/up":
+		return trigger("word_spacing_up")
+	case "spacing/down":
+		return trigger("word_spacing_down")
+	case "speed":
+		value, ok := oscNumber(message.Args, 0)
+		if !ok {
+			return fmt.Errorf("%s needs a numeric argument", message.Address)
+		}
+		return dispatch(s.actions, Command{Action: "set_speed", Value: value})
+	case "goto":
+		value, ok := oscNumber(message.Args, 0)
+		if !ok || value < 1 {
+			return fmt.Errorf("%s needs a section number starting at 1", message.Address)
+		}
+		return dispatch(s.actions, Command{Action: "jump", Section: int(value) - 1})
+	case "load":
+		name, ok := oscString(message.Args, 0)
+		if !ok || name == "" {
+			return fmt.Errorf("%s needs a file name", message.Address)
+		}
+		path, err := scriptPath(s.scriptsDir, name)
+		if err != nil {
+			return err
+		}
+		return dispatch(s.actions, Command{Action: "load", Path: path})
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownAction, message.Address)
+	}
+}
+
+func (s *OSCServer) loop() {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(broadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sendFeedback()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *OSCServer) sendFeedback() {
+	if s.feedback == nil {
+		return
+	}
+	s.mu.Lock()
+	state := s.state
+	previous := s.sent
+	s.sent = &state
+	s.mu.Unlock()
+
+	for _, message := range feedbackMessages(previous, state) {
+		packet, err := EncodeOSC(message)
+		if err != nil {
+			continue
+		}
+		_, _ = s.conn.WriteToUDP(packet, s.feedback)
+	}
+}
+
+func feedbackMessages(previous *State, state State) []OSCMessage {
+	playing := int32(0)
+	if state.Playing {
+		playing = 1
+	}
+
+	var messages []OSCMessage
+	if previous == nil || previous.Playing != state.Playing {
+		messages = append(messages, OSCMessage{Address: oscPrefix + "playing", Args: []any{playing}})
+	}
+	if previous == nil || previous.Speed != state.Speed {
+		messages = append(messages, OSCMessage{Address: oscPrefix + "speed", Args: []any{float32(state.Speed)}})
+	}
+	if previous == nil || previous.Position != state.Position {
+		messages = append(messages, OSCMessage{Address: oscPrefix + "position", Args: []any{float32(state.Position)}})
+	}
+	return messages
+}
+
+func oscNumber(args []any, index int) (float64, bool) {
+	if index >= len(args) {
+		return 0, false
+	}
+	switch value := args[index].(type) {
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float32:
+		return float64(value), true
+	case float64:
+		return value, true
+	case bool:
+		if value {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+func oscString(args []any, index int) (string, bool) {
+	if index >= len(args) {
+		return "", false
+	}
+	value, ok := args[index].(string)
+	return value, ok
+}
+
+func EncodeOSC(message OSCMessage) ([]byte, error) {
+	if !strings.HasPrefix(message.Address, "/") {
+		return nil, fmt.Errorf("invalid OSC address %q", message.Address)
+	}
+
+	var tags strings.Builder
+	tags.WriteByte(',')
+	var args bytes.Buffer
+	for _, arg := range message.Args {
+		switch value := arg.(type) {
+		case int32:
+			tags.WriteByte('i')
+			_ = binary.Write(&args, binary.BigEndian, value)
+		case int:
+			tags.WriteByte('i')
+			_ = binary.Write(&args, binary.BigEndian, int32(value))
+		case float32:
+			tags.WriteByte('f')
+			_ = binary.Write(&args, binary.BigEndian, math.Float32bits(value))
+		case float64:
+			tags.WriteByte('f')
+			_ = binary.Write(&args, binary.BigEndian, math.Float32bits(float32(value)))
+		case string:
+			tags.WriteByte('s')
+			writeOSCString(&args, value)
+		case bool:
+			if value {
+				tags.WriteByte('T')
+			} else {
+				tags.WriteByte('F')
+			}
+		default:
+			return nil, fmt.Errorf("unsupported OSC argument %T", arg)
+		}
+	}
+
+	var packet bytes.Buffer
+	writeOSCString(&packet, message.Address)
+	writeOSCString(&packet, tags.String())
+	packet.Write(args.Bytes())
+	return packet.Bytes(), nil
+}
+
+func DecodeOSC(packet []byte) ([]OSCMessage, error) {
+	if bytes.HasPrefix(packet, []byte(oscBundleTag+"\x00")) {
+		return decodeOSCBundle(packet)
+	}
+	message, err := decodeOSCMessage(packet)
+	if err != nil {
+		return nil, err
+	}
+	return []OSCMessage{message}, nil
+}
+
+func decodeOSCBundle(packet []byte) ([]OSCMessage, error) {
+	if len(packet) < 16 {
+		return nil, errMalformedOSC
+	}
+	rest := packet[16:]
+
+	var messages []OSCMessage
+	for len(rest) > 0 {
+		if len(rest) < 4 {
+			return nil, errMalformedOSC
+		}
+		size := int(binary.BigEndian.Uint32(rest))
+		rest = rest[4:]
+		if size <= 0 || size > len(rest) || size%4 != 0 {
+			return nil, errMalformedOSC
+		}
+		element, err := DecodeOSC(rest[:size])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, element...)
+		rest = rest[size:]
+	}
+	return messages, nil
+}
+
+func decodeOSCMessage(packet []byte) (OSCMessage, error) {
+	address, rest, err := readOSCString(packet)
+	if err != nil || !strings.HasPrefix(address, "/") {
+		return OSCMessage{}, errMalformedOSC
+	}
+
+	message := OSCMessage{Address: address}
+	if len(rest) == 0 {
+		return message, nil
+	}
+	tags, rest, err := readOSCString(rest)
+	if err != nil || !strings.HasPrefix(tags, ",") {
+		return OSCMessage{}, errMalformedOSC
+	}
+
+	for _, tag := range tags[1:] {
+		switch tag {
+		case 'i':
+			if len(rest) < 4 {
+				return OSCMessage{}, errMalformedOSC
+			}
+			message.Args = append(message.Args, int32(binary.BigEndian.Uint32(rest)))
+			rest = rest[4:]
+		case 'f':
+			if len(rest) < 4 {
+				return OSCMessage{}, errMalformedOSC
+			}
+			message.Args = append(message.Args, math.Float32frombits(binary.BigEndian.Uint32(rest)))
+			rest = rest[4:]
+		case 'h':
+			if len(rest) < 8 {
+				return OSCMessage{}, errMalformedOSC
+			}
+			message.Args = append(message.Args, int64(binary.BigEndian.Uint64(rest)))
+			rest = rest[8:]
+		case 'd':
+			if len(rest) < 8 {
+				return OSCMessage{}, errMalformedOSC
+			}
+			message.Args = append(message.Args, math.Float64frombits(binary.BigEndian.Uint64(rest)))
+			rest = rest[8:]
+		case 's', 'S':
+			var value string
+			value, rest, err = readOSCString(rest)
+			if err != nil {
+				return OSCMessage{}, err
+			}
+			message.Args = append(message.Args, value)
+		case 'b':
+			if len(rest) < 4 {
+				return OSCMessage{}, errMalformedOSC
+			}
+			size := int(binary.BigEndian.Uint32(rest))
+			padded := 4 + (size+3)/4*4
+			if size < 0 || padded > len(rest) {
+				return OSCMessage{}, errMalformedOSC
+			}
+			message.Args = append(message.Args, append([]byte(nil), rest[4:4+size]...))
+			rest = rest[padded:]
+		case 'T':
+			message.Args = append(message.Args, true)
+		case 'F':
+			message.Args = append(message.Args, false)
+		case 'N', 'I':
+			message.Args = append(message.Args, nil)
+		default:
+			return OSCMessage{}, fmt.Errorf("%w: unsupported type tag %q", errMalformedOSC, tag)
+		}
+	}
+	return message, nil
+}
+
+func writeOSCString(buffer *bytes.Buffer, value string) {
+	buffer.WriteString(value)
+	padding := 4 - len(value)%4
+	buffer.Write(make([]byte, padding))
+}
+
+func readOSCString(data []byte) (string, []byte, error) {
+	end := bytes.IndexByte(data, 0)
+	if end < 0 {
+		return "", nil, errMalformedOSC
+	}
+	padded := (end + 4) / 4 * 4
+	if padded > len(data) {
+		return "", nil, errMalformedOSC
+	}
+	return string(data[:end]), data[padded:], nil
+}
@@ -0,0 +1,192 @@
+package remote
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func readOSC(t *testing.T, conn *net.UDPConn) OSCMessage {
+	t.Helper()
+
+	buffer := make([]byte, oscMaxPacket)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFromUDP(buffer)
+	if err != nil {
+		t.Fatalf("read feedback: %v", err)
+	}
+	messages, err := DecodeOSC(buffer[:n])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("decode feedback: %v (%d messages)", err, len(messages))
+	}
+	return messages[0]
+}
+
+func TestOSCRoundTrip(t *testing.T) {
+	message := OSCMessage{Address: "/grompt/load", Args: []any{"/tmp/a.md", int32(3), float32(1.5), true}}
+
+	packet, err := EncodeOSC(message)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if len(packet)%4 != 0 {
+		t.Fatalf("expected a 4-byte aligned packet, got %d bytes", len(packet))
+	}
+	decoded, err := DecodeOSC(packet)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, []OSCMessage{message}) {
+		t.Fatalf("expected %+v, got %+v", message, decoded)
+	}
+
+	if _, err := DecodeOSC(packet[:len(packet)-2]); err == nil {
+		t.Fatal("expected an error for a truncated packet")
+	}
+}
+
+func TestOSCServerLoopback(t *testing.T) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer conn.Close()
+
+	calls := make(chan string, 4)
+	server, err := NewOSCServer(Actions{
+		Play:          func() { calls <- "play" },
+		Pause:         func() { calls <- "pause" },
+		SetSpeed:      func(speed float64) { calls <- "speed" },
+		JumpToSection: func(index int) { calls <- "jump" },
+		FullScreen:    func() { calls <- "fullscreen" },
+	}, conn.LocalAddr().String(), "")
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	if err := server.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer server.Close()
+
+	serverAddr, err := net.ResolveUDPAddr("udp", server.Addr())
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	for _, message := range []OSCMessage{
+		{Address: "/grompt/play"},
+		{Address: "/grompt/pause", Args: []any{float32(0)}},
+		{Address: "/grompt/speed", Args: []any{float32(80)}},
+		{Address: "/grompt/goto", Args: []any{int32(2)}},
+		{Address: "/grompt/fullscreen", Args: []any{int32(1)}},
+	} {
+		packet, err := EncodeOSC(message)
+		if err != nil {
+			t.Fatalf("encode %s: %v", message.Address, err)
+		}
+		if _, err := conn.WriteToUDP(packet, serverAddr); err != nil {
+			t.Fatalf("send %s: %v", message.Address, err)
+		}
+	}
+
+	for _, expected := range []string{"play", "speed", "jump", "fullscreen"} {
+		select {
+		case got := <-calls:
+			if got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %q", expected)
+		}
+	}
+
+	server.Publish(State{Playing: true, Speed: 80, Position: 0.5})
+	want := map[string]any{
+		"/grompt/playing":  int32(1),
+		"/grompt/speed":    float32(80),
+		"/grompt/position": float32(0.5),
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for len(want) > 0 && time.Now().Before(deadline) {
+		message := readOSC(t, conn)
+		if expected, ok := want[message.Address]; ok && len(message.Args) == 1 && message.Args[0] == expected {
+			delete(want, message.Address)
+		}
+	}
+	if len(want) > 0 {
+		t.Fatalf("missing feedback %v", want)
+	}
+}
+
+func TestOSCServerBindsLoopbackAndStaysQuiet(t *testing.T) {
+	server, err := NewOSCServer(Actions{}, "", "")
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	if err := server.Start(":0"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer server.Close()
+
+	serverAddr, err := net.ResolveUDPAddr("udp", server.Addr())
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if !serverAddr.IP.IsLoopback() {
+		t.Fatalf("expected a port-only address to bind loopback, got %s", serverAddr)
+	}
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer conn.Close()
+	packet, _ := EncodeOSC(OSCMessage{Address: "/grompt/play"})
+	if _, err := conn.WriteToUDP(packet, serverAddr); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	server.Publish(State{Playing: true})
+
+	_ = conn.SetReadDeadline(time.Now().Add(3 * broadcastInterval))
+	if n, _, err := conn.ReadFromUDP(make([]byte, oscMaxPacket)); err == nil {
+		t.Fatalf("expected no feedback to the sender, got %d bytes", n)
+	}
+}
+
+func TestOSCServerConfinesLoad(t *testing.T) {
+	scripts := t.TempDir()
+	if err := os.WriteFile(filepath.Join(scripts, "show.md"), []byte("# Show"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded []string
+	actions := Actions{LoadFile: func(path string) { loaded = append(loaded, path) }}
+	server, err := NewOSCServer(actions, "", scripts)
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	if err := server.Dispatch(OSCMessage{Address: "/grompt/load", Args: []any{"show.md"}}); err != nil {
+		t.Fatalf("load inside the scripts directory: %v", err)
+	}
+	if err := server.Dispatch(OSCMessage{Address: "/grompt/load", Args: []any{"/etc/passwd"}}); err == nil {
+		t.Fatal("expected a file outside the scripts directory to be refused")
+	}
+	if err := server.Dispatch(OSCMessage{Address: "/grompt/load"}); err == nil {
+		t.Fatal("expected an error for a missing file name")
+	}
+	if len(loaded) != 1 || filepath.Base(loaded[0]) != "show.md" {
+		t.Fatalf("unexpected loads %v", loaded)
+	}
+
+	disabled, err := NewOSCServer(actions, "", "")
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	if err := disabled.Dispatch(OSCMessage{Address: "/grompt/load", Args: []any{"show.md"}}); !errors.Is(err, ErrLoadDisabled) {
+		t.Fatalf("expected ErrLoadDisabled, got %v", err)
+	}
+}
@@ -0,0 +1,400 @@
+package remote
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const broadcastInterval = 100 * time.Millisecond
+
+var ErrUnknownAction = errors.New("unknown action")
+
+//go:embed page.html
+var remotePage []byte
+
+type Actions struct {
+	Play            func()
+	Pause           func()
+	Toggle          func()
+	SpeedUp         func()
+	SpeedDown       func()
+	SetSpeed        func(speed float64)
+	FontSizeUp      func()
+	FontSizeDown    func()
+	WordSpacingUp   func()
+	WordSpacingDown func()
+	JumpToSection   func(index int)
+	LoadFile        func(path string)
+	FullScreen      func()
+}
+
+type Section struct {
+	Title string `json:"title"`
+	Level int    `json:"level"`
+}
+
+type State struct {
+	Playing     bool      `json:"playing"`
+	Speed       float64   `json:"speed"`
+	SpeedUnit   string    `json:"speed_unit"`
+	Position    float64   `json:"position"`
+	FontSize    float32   `json:"font_size"`
+	WordSpacing int       `json:"word_spacing"`
+	FileName    string    `json:"file_name"`
+	Sections    []Section `json:"sections"`
+}
+
+type Command struct {
+	Action  string  `json:"action"`
+	Value   float64 `json:"value,omitempty"`
+	Section int     `json:"section,omitempty"`
+	Path    string  `json:"path,omitempty"`
+}
+
+type Message struct {
+	Type  string `json:"type"`
+	State *State `json:"state,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type client struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+type Server struct {
+	actions    Actions
+	token      string
+	scriptsDir string
+	listenHost string
+
+	mu      sync.Mutex
+	state   State
+	dirty   bool
+	clients map[*client]struct{}
+
+	httpServer *http.Server
+	listener   net.Listener
+	stopCh     chan struct{}
+	doneCh     chan struct{}
+	once       sync.Once
+}
+
+func NewServer(actions Actions, token, scriptsDir string) *Server {
+	server := &Server{
+		actions:    actions,
+		token:      token,
+		scriptsDir: scriptsDir,
+		clients:    map[*client]struct{}{},
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+
+	go server.loop()
+	return server
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.servePage)
+	mux.HandleFunc("/api/state", s.serveState)
+	mux.Handle("/ws", websocket.Server{
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			origin, err := websocket.Origin(config, req)
+			if err != nil || !sameOrigin(origin, req) {
+				return errors.New("cross-origin connection refused")
+			}
+			config.Origin = origin
+			if !s.authorized(req) {
+				return errors.New("invalid token")
+			}
+			return nil
+		},
+		Handler: s.serveWebSocket,
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !s.trustedHost(req.Host) {
+			http.Error(w, "unknown host", http.StatusForbidden)
+			return
+		}
+		mux.ServeHTTP(w, req)
+	})
+}
+
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("start remote control: %w", err)
+	}
+	if s.token == "" && !loopback(listener.Addr()) {
+		_ = listener.Close()
+		return fmt.Errorf("remote control on %s is reachable from other machines and needs remote_token", addr)
+	}
+
+	s.listener = listener
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		s.listenHost = host
+	}
+	s.httpServer = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		_ = s.httpServer.Serve(listener)
+	}()
+	return nil
+}
+
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+func (s *Server) Publish(state State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if reflect.DeepEqual(s.state, state) {
+		return
+	}
+	s.state = state
+	s.dirty = true
+}
+
+func (s *Server) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.stopCh)
+		<-s.doneCh
+		if s.httpServer != nil {
+			err = s.httpServer.Close()
+		}
+
+		s.mu.Lock()
+		for c := range s.clients {
+			_ = c.conn.Close()
+		}
+		s.clients = map[*client]struct{}{}
+		s.mu.Unlock()
+	})
+	return err
+}
+
+func (s *Server) loop() {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(broadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.broadcast()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *Server) broadcast() {
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return
+	}
+	s.dirty = false
+	state := s.state
+	clients := make([]*client, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range clients {
+		if err := c.send(Message{Type: "state", State: &state}); err != nil {
+			s.removeClient(c)
+		}
+	}
+}
+
+func (s *Server) servePage(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/" {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(remotePage)
+}
+
+func (s *Server) serveState(w http.ResponseWriter, req *http.Request) {
+	if !s.authorized(req) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	state := s.state
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+func (s *Server) serveWebSocket(conn *websocket.Conn) {
+	c := &client{conn: conn}
+
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	state := s.state
+	s.mu.Unlock()
+	defer s.removeClient(c)
+
+	if err := c.send(Message{Type: "state", State: &state}); err != nil {
+		return
+	}
+
+	for {
+		var command Command
+		if err := websocket.JSON.Receive(conn, &command); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				if c.send(Message{Type: "error", Error: "invalid command"}) != nil {
+					return
+				}
+				continue
+			}
+			return
+		}
+		if err := s.Dispatch(command); err != nil {
+			if c.send(Message{Type: "error", Error: err.Error()}) != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) Dispatch(command Command) error {
+	if command.Action == "load" && command.Path != "" {
+		path, err := scriptPath(s.scriptsDir, command.Path)
+		if err != nil {
+			return err
+		}
+		command.Path = path
+	}
+	return dispatch(s.actions, command)
+}
+
+func dispatch(actions Actions, command Command) error {
+	var action func()
+	switch command.Action {
+	case "play":
+		action = actions.Play
+	case "pause":
+		action = actions.Pause
+	case "toggle":
+		action = actions.Toggle
+	case "speed_up":
+		action = actions.SpeedUp
+	case "speed_down":
+		action = actions.SpeedDown
+	case "set_speed":
+		if command.Value <= 0 {
+			return fmt.Errorf("invalid speed %v", command.Value)
+		}
+		if actions.SetSpeed != nil {
+			actions.SetSpeed(command.Value)
+		}
+		return nil
+	case "font_size_up":
+		action = actions.FontSizeUp
+	case "font_size_down":
+		action = actions.FontSizeDown
+	case "word_spacing_up":
+		action = actions.WordSpacingUp
+	case "word_spacing_down":
+		action = actions.WordSpacingDown
+	case "fullscreen":
+		action = actions.FullScreen
+	case "jump":
+		if command.Section < 0 {
+			return fmt.Errorf("invalid section %d", command.Section)
+		}
+		if actions.JumpToSection != nil {
+			actions.JumpToSection(command.Section)
+		}
+		return nil
+	case "load":
+		if command.Path == "" {
+			return errors.New("missing path")
+		}
+		if actions.LoadFile != nil {
+			actions.LoadFile(command.Path)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownAction, command.Action)
+	}
+
+	if action != nil {
+		action()
+	}
+	return nil
+}
+
+func (s *Server) authorized(req *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(req.URL.Query().Get("token")), []byte(s.token)) == 1
+}
+
+func (s *Server) trustedHost(hostport string) bool {
+	if s.token != "" {
+		return true
+	}
+	host := hostport
+	if name, _, err := net.SplitHostPort(hostport); err == nil {
+		host = name
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if strings.EqualFold(host, "localhost") || (s.listenHost != "" && strings.EqualFold(host, s.listenHost)) {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func sameOrigin(origin *url.URL, req *http.Request) bool {
+	return origin == nil || strings.EqualFold(origin.Host, req.Host)
+}
+
+func loopback(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	return ok && tcpAddr.IP.IsLoopback()
+}
+
+func (s *Server) removeClient(c *client) {
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+	_ = c.conn.Close()
+}
+
+func (c *client) send(message Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(2 * time.Second))
+	return websocket.JSON.Send(c.conn, message)
+}
@@ -0,0 +1,280 @@
+package remote
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func dialTestServer(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws" + query
+	conn, err := websocket.Dial(url, "", server.URL)
+	if err != nil {
+		t.Fatalf("dial websocket: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func receiveMessage(t *testing.T, conn *websocket.Conn) Message {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var message Message
+	if err := websocket.JSON.Receive(conn, &message); err != nil {
+		t.Fatalf("receive message: %v", err)
+	}
+	return message
+}
+
+func TestServerDispatchesCommands(t *testing.T) {
+	scripts := t.TempDir()
+	script := filepath.Join(scripts, "script.md")
+	if err := os.WriteFile(script, []byte("# Show"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	scripts, _ = filepath.EvalSymlinks(scripts)
+
+	calls := make(chan string, 5)
+	server := NewServer(Actions{
+		Toggle:        func() { calls <- "toggle" },
+		SetSpeed:      func(speed float64) { calls <- "speed" },
+		JumpToSection: func(index int) { calls <- "jump" },
+		LoadFile:      func(path string) { calls <- "load " + path },
+		FullScreen:    func() { calls <- "fullscreen" },
+	}, "", scripts)
+	defer server.Close()
+
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	conn := dialTestServer(t, httpServer, "")
+	if message := receiveMessage(t, conn); message.Type != "state" {
+		t.Fatalf("expected the initial state, got %+v", message)
+	}
+
+	for _, command := range []Command{
+		{Action: "toggle"},
+		{Action: "set_speed", Value: 80},
+		{Action: "jump", Section: 2},
+		{Action: "load", Path: "script.md"},
+		{Action: "fullscreen"},
+	} {
+		if err := websocket.JSON.Send(conn, command); err != nil {
+			t.Fatalf("send %s: %v", command.Action, err)
+		}
+	}
+
+	want := []string{"toggle", "speed", "jump", "load " + filepath.Join(scripts, "script.md"), "fullscreen"}
+	for _, expected := range want {
+		select {
+		case got := <-calls:
+			if got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %q", expected)
+		}
+	}
+
+	if err := websocket.JSON.Send(conn, Command{Action: "explode"}); err != nil {
+		t.Fatalf("send unknown command: %v", err)
+	}
+	if message := receiveMessage(t, conn); message.Type != "error" {
+		t.Fatalf("expected an error message, got %+v", message)
+	}
+}
+
+func TestServerPushesState(t *testing.T) {
+	server := NewServer(Actions{}, "", "")
+	defer server.Close()
+
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	conn := dialTestServer(t, httpServer, "")
+	receiveMessage(t, conn)
+
+	server.Publish(State{Playing: true, Speed: 120, Position: 0.25, Sections: []Section{{Title: "Intro", Level: 1}}})
+	message := receiveMessage(t, conn)
+	if message.Type != "state" || message.State == nil {
+		t.Fatalf("expected a state message, got %+v", message)
+	}
+	if !message.State.Playing || message.State.Speed != 120 || message.State.Position != 0.25 || len(message.State.Sections) != 1 {
+		t.Fatalf("unexpected state %+v", message.State)
+	}
+}
+
+func TestServerRequiresToken(t *testing.T) {
+	server := NewServer(Actions{}, "secret", "")
+	defer server.Close()
+
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+	if _, err := websocket.Dial(url, "", httpServer.URL); err == nil {
+		t.Fatal("expected the handshake to fail without a token")
+	}
+	dialTestServer(t, httpServer, "?token=secret")
+
+	response, err := http.Get(httpServer.URL + "/")
+	if err != nil {
+		t.Fatalf("get page: %v", err)
+	}
+	defer response.Body.Close()
+	page, _ := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK || !strings.Contains(string(page), "<html") {
+		t.Fatalf("expected the remote page, got %d", response.StatusCode)
+	}
+}
+
+func TestDispatchRejectsInvalidCommands(t *testing.T) {
+	server := NewServer(Actions{}, "", "")
+	defer server.Close()
+
+	if err := server.Dispatch(Command{Action: "nope"}); !errors.Is(err, ErrUnknownAction) {
+		t.Fatalf("expected ErrUnknownAction, got %v", err)
+	}
+	if err := server.Dispatch(Command{Action: "set_speed"}); err == nil {
+		t.Fatal("expected an error for a missing speed")
+	}
+	if err := server.Dispatch(Command{Action: "load"}); err == nil {
+		t.Fatal("expected an error for a missing path")
+	}
+}
+
+func TestServerRejectsCrossOriginConnections(t *testing.T) {
+	server := NewServer(Actions{}, "", "")
+	defer server.Close()
+
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+	if _, err := websocket.Dial(url, "", "http://evil.example"); err == nil {
+		t.Fatal("expected a connection from another origin to be refused")
+	}
+	dialTestServer(t, httpServer, "")
+}
+
+func TestServerRejectsRebindingHosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		host   string
+		status int
+	}{
+		{name: "loopback ip", host: "127.0.0.1:8080", status: http.StatusOK},
+		{name: "loopback ipv6", host: "[::1]:8080", status: http.StatusOK},
+		{name: "localhost", host: "LocalHost:8080", status: http.StatusOK},
+		{name: "rebinding", host: "evil.example", status: http.StatusForbidden},
+		{name: "rebinding with port", host: "evil.example:8080", status: http.StatusForbidden},
+		{name: "token", token: "secret", host: "prompter.example:8080", status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(Actions{}, tt.token, "")
+			defer server.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/state?token="+tt.token, nil)
+			req.Host = tt.host
+			req.Header.Set("Origin", "http://"+tt.host)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, req)
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestServerRefusesRebindingWebSocket(t *testing.T) {
+	server := NewServer(Actions{Play: func() { t.Fatal("a rebinding page ran an action") }}, "", "")
+	defer server.Close()
+
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	config, err := websocket.NewConfig("ws://evil.example/ws", "http://evil.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.Dial("tcp", strings.TrimPrefix(httpServer.URL, "http://"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if _, err := websocket.NewClient(config, conn); err == nil {
+		t.Fatal("expected a WebSocket with Host evil.example to be refused")
+	}
+}
+
+func TestServerNeedsTokenBeyondLoopback(t *testing.T) {
+	open := NewServer(Actions{}, "", "")
+	defer open.Close()
+	if err := open.Start("0.0.0.0:0"); err == nil {
+		t.Fatal("expected a server on all interfaces without a token to be refused")
+	}
+
+	local := NewServer(Actions{}, "", "")
+	defer local.Close()
+	if err := local.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("start on loopback: %v", err)
+	}
+
+	protected := NewServer(Actions{}, "secret", "")
+	defer protected.Close()
+	if err := protected.Start("0.0.0.0:0"); err != nil {
+		t.Fatalf("start with a token: %v", err)
+	}
+}
+
+func TestServerConfinesLoadToScriptsDir(t *testing.T) {
+	root := t.TempDir()
+	scripts := filepath.Join(root, "scripts")
+	if err := os.MkdirAll(filepath.Join(scripts, "shows"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{filepath.Join(scripts, "shows", "news.md"), filepath.Join(root, "secret.txt")} {
+		if err := os.WriteFile(name, []byte("text"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(root, "secret.txt"), filepath.Join(scripts, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded []string
+	server := NewServer(Actions{LoadFile: func(path string) { loaded = append(loaded, path) }}, "", scripts)
+	defer server.Close()
+
+	if err := server.Dispatch(Command{Action: "load", Path: "shows/news.md"}); err != nil {
+		t.Fatalf("load inside the scripts directory: %v", err)
+	}
+	for _, path := range []string{"../secret.txt", filepath.Join(root, "secret.txt"), "link.txt", "missing.md", "."} {
+		if err := server.Dispatch(Command{Action: "load", Path: path}); err == nil {
+			t.Fatalf("expected loading %q to be refused", path)
+		}
+	}
+	if len(loaded) != 1 || filepath.Base(loaded[0]) != "news.md" {
+		t.Fatalf("unexpected loads %v", loaded)
+	}
+
+	disabled := NewServer(Actions{LoadFile: func(string) { t.Fatal("load ran without a scripts directory") }}, "", "")
+	defer disabled.Close()
+	if err := disabled.Dispatch(Command{Action: "load", Path: "shows/news.md"}); !errors.Is(err, ErrLoadDisabled) {
+		t.Fatalf("expected ErrLoadDisabled, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package scroll
+
+import (
+	"sort"
+	"time"
+)
+
+type CueKind string
+
+const (
+	CuePause CueKind = "pause"
+	CueSpeed CueKind = "speed"
+	CueWait  CueKind = "wait"
+)
+
+type Cue struct {
+	Offset float64
+	Kind   CueKind
+	Speed  float64
+	Wait   time.Duration
+}
+
+func (e *Engine) SetCues(cues []Cue) {
+	sorted := append([]Cue(nil), cues...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cues = sorted
+}
+
+func (e *Engine) SetOnCue(onCue func(Cue)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onCue = onCue
+}
+
+func (e *Engine) CrossCues(from, to float64) {
+	if to <= from {
+		return
+	}
+
+	e.mu.Lock()
+	var crossed []Cue
+	for _, cue := range e.cues {
+		if cue.Offset > from && cue.Offset <= to {
+			crossed = append(crossed, cue)
+		}
+	}
+	onCue := e.onCue
+	e.mu.Unlock()
+
+	for _, cue := range crossed {
+		e.applyCue(cue)
+		if onCue != nil {
+			onCue(cue)
+		}
+	}
+}
+
+func (e *Engine) applyCue(cue Cue) {
+	switch cue.Kind {
+	case CuePause:
+		e.Pause()
+	case CueSpeed:
+		e.SetSpeed(cue.Speed)
+	case CueWait:
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.playing = false
+		e.retargetLocked()
+		e.resumeAt = e.clock.Now().Add(cue.Wait)
+	}
+}
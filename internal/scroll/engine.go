@@ -1,6 +1,7 @@
 package scroll
 
 import (
+	"math"
 	"sync"
 	"time"
 )
@@ -11,74 +12,312 @@ const (
 	DefaultStep     = 20.0
 	DefaultSpeed    = 20.0
 	DefaultTickRate = 33 * time.Millisecond
+	MaxFrameDelta   = 250 * time.Millisecond
+
+	DefaultEasing       = EasingEaseInOut
+	DefaultRampDuration = 300 * time.Millisecond
+	MaxRampDuration     = 2 * time.Second
 )
 
+type Easing string
+
+const (
+	EasingNone      Easing = "none"
+	EasingLinear    Easing = "linear"
+	EasingEaseInOut Easing = "ease-in-out"
+)
+
+func ParseEasing(value string) (Easing, bool) {
+	switch Easing(value) {
+	case EasingNone, EasingLinear, EasingEaseInOut:
+		return Easing(value), true
+	default:
+		return "", false
+	}
+}
+
+func ClampRampDuration(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return 0
+	}
+	if duration > MaxRampDuration {
+		return MaxRampDuration
+	}
+	return duration
+}
+
+func (easing Easing) apply(progress float64) float64 {
+	if progress <= 0 {
+		return 0
+	}
+	if progress >= 1 {
+		return 1
+	}
+	switch easing {
+	case EasingLinear:
+		return progress
+	case EasingEaseInOut:
+		return progress * progress * (3 - 2*progress)
+	default:
+		return 1
+	}
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
 type Engine struct {
-	mu      sync.Mutex
-	speed   float64
-	min     float64
-	max     float64
-	step    float64
-	playing bool
+	mu       sync.Mutex
+	speed    float64
+	min      float64
+	max      float64
+	step     float64
+	playing  bool
+	reverse  bool
+	clock    Clock
+	lastTick time.Time
 
-	ticker  *time.Ticker
-	stopCh  chan struct{}
-	onDelta func(float64)
+	easing       Easing
+	rampDuration time.Duration
+	rampSpan     time.Duration
+	rampFrom     float64
+	rampTo       float64
+	rampStart    time.Time
+
+	cues     []Cue
+	onCue    func(Cue)
+	resumeAt time.Time
+
+	ticker   *time.Ticker
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	onDelta  func(float64)
 }
 
 func NewEngine(onDelta func(float64)) *Engine {
-	engine := &Engine{
-		speed:   DefaultSpeed,
-		min:     DefaultMinSpeed,
-		max:     DefaultMaxSpeed,
-		step:    DefaultStep,
-		ticker:  time.NewTicker(DefaultTickRate),
-		stopCh:  make(chan struct{}),
-		onDelta: onDelta,
-	}
+	engine := newEngine(onDelta, systemClock{})
+	engine.ticker = time.NewTicker(DefaultTickRate)
 
 	go engine.loop()
 	return engine
 }
 
+func NewFrameEngine(onDelta func(float64), clock Clock) *Engine {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return newEngine(onDelta, clock)
+}
+
+func newEngine(onDelta func(float64), clock Clock) *Engine {
+	return &Engine{
+		speed:        DefaultSpeed,
+		min:          DefaultMinSpeed,
+		max:          DefaultMaxSpeed,
+		step:         DefaultStep,
+		clock:        clock,
+		easing:       DefaultEasing,
+		rampDuration: DefaultRampDuration,
+		rampSpan:     DefaultRampDuration,
+		stopCh:       make(chan struct{}),
+		onDelta:      onDelta,
+	}
+}
+
 func (e *Engine) loop() {
-	deltaSeconds := DefaultTickRate.Seconds()
 	for {
 		select {
 		case <-e.stopCh:
 			return
 		case <-e.ticker.C:
-			e.mu.Lock()
-			playing := e.playing
-			speed := e.speed
-			e.mu.Unlock()
-
-			if playing && e.onDelta != nil {
-				e.onDelta(speed * deltaSeconds)
-			}
+			e.Advance()
 		}
 	}
 }
 
+func (e *Engine) Advance() {
+	e.mu.Lock()
+	now := e.clock.Now()
+	if !e.resumeAt.IsZero() && !now.Before(e.resumeAt) {
+		e.resumeAt = time.Time{}
+		e.startLocked()
+	}
+	last := e.lastTick
+	e.lastTick = now
+	if last.IsZero() || e.onDelta == nil {
+		e.mu.Unlock()
+		return
+	}
+	elapsed := now.Sub(last)
+	if elapsed > MaxFrameDelta {
+		last = now.Add(-MaxFrameDelta)
+		elapsed = MaxFrameDelta
+	}
+	startVelocity := e.velocityAtLocked(last)
+	endVelocity := e.velocityAtLocked(now)
+	e.mu.Unlock()
+
+	if elapsed <= 0 || (startVelocity == 0 && endVelocity == 0) {
+		return
+	}
+	e.onDelta((startVelocity + endVelocity) / 2 * elapsed.Seconds())
+}
+
 func (e *Engine) Play() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	e.playing = true
+	e.startLocked()
 }
 
 func (e *Engine) Pause() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	e.resumeAt = time.Time{}
+	e.playing = false
+	e.retargetLocked()
+}
+
+func (e *Engine) PauseWithin(distance float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.resumeAt = time.Time{}
 	e.playing = false
+	velocity := math.Abs(e.velocityAtLocked(e.clock.Now()))
+	e.retargetLocked()
+	if velocity > 0 && e.easing != EasingNone {
+		e.rampSpan = min(e.rampDuration, time.Duration(2*math.Max(distance, 0)/velocity*float64(time.Second)))
+	}
+}
+
+func (e *Engine) PauseImmediately() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.resumeAt = time.Time{}
+	e.playing = false
+	e.rampFrom = 0
+	e.rampTo = 0
+	e.rampStart = e.clock.Now()
 }
 
 func (e *Engine) Toggle() bool {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	e.playing = !e.playing
+	if e.playing {
+		e.resumeAt = time.Time{}
+		e.playing = false
+		e.retargetLocked()
+	} else {
+		e.startLocked()
+	}
 	return e.playing
 }
 
+func (e *Engine) startLocked() {
+	e.resumeAt = time.Time{}
+	now := e.clock.Now()
+	if !e.playing && e.velocityAtLocked(now) == 0 {
+		e.lastTick = now
+	}
+	e.playing = true
+	e.retargetLocked()
+}
+
+func (e *Engine) retargetLocked() {
+	now := e.clock.Now()
+	target := 0.0
+	if e.playing {
+		target = e.speed
+		if e.reverse {
+			target = -target
+		}
+	}
+	e.rampFrom = e.velocityAtLocked(now)
+	e.rampTo = target
+	e.rampStart = now
+	e.rampSpan = e.rampDuration
+}
+
+func (e *Engine) velocityAtLocked(at time.Time) float64 {
+	if e.easing == EasingNone || e.rampSpan <= 0 {
+		return e.rampTo
+	}
+	progress := float64(at.Sub(e.rampStart)) / float64(e.rampSpan)
+	return e.rampFrom + (e.rampTo-e.rampFrom)*e.easing.apply(progress)
+}
+
+func (e *Engine) SetEasing(easing Easing, duration time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := ParseEasing(string(easing)); !ok {
+		easing = DefaultEasing
+	}
+	now := e.clock.Now()
+	current := e.velocityAtLocked(now)
+	e.easing = easing
+	e.rampDuration = ClampRampDuration(duration)
+	e.rampSpan = e.rampDuration
+	e.rampFrom = current
+	e.rampStart = now
+}
+
+func (e *Engine) Easing() (Easing, time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.easing, e.rampDuration
+}
+
+func (e *Engine) Velocity() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.velocityAtLocked(e.clock.Now())
+}
+
+func (e *Engine) StoppingDistance() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.easing == EasingNone {
+		return 0
+	}
+	return math.Abs(e.velocityAtLocked(e.clock.Now())) * e.rampDuration.Seconds() / 2
+}
+
+func (e *Engine) StoppingTime() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.easing == EasingNone {
+		return 0
+	}
+	return e.rampDuration / 2
+}
+
+func (e *Engine) SetReverse(reverse bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reverse = reverse
+	e.retargetLocked()
+}
+
+func (e *Engine) ToggleReverse() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reverse = !e.reverse
+	e.retargetLocked()
+	return e.reverse
+}
+
+func (e *Engine) IsReverse() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.reverse
+}
+
 func (e *Engine) IsPlaying() bool {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -98,6 +337,7 @@ func (e *Engine) SpeedUp() float64 {
 	if e.speed > e.max {
 		e.speed = e.max
 	}
+	e.retargetLocked()
 	return e.speed
 }
 
@@ -108,6 +348,7 @@ func (e *Engine) SpeedDown() float64 {
 	if e.speed < e.min {
 		e.speed = e.min
 	}
+	e.retargetLocked()
 	return e.speed
 }
 
@@ -121,10 +362,15 @@ func (e *Engine) SetSpeed(speed float64) float64 {
 		speed = e.max
 	}
 	e.speed = speed
+	e.retargetLocked()
 	return e.speed
 }
 
 func (e *Engine) Stop() {
-	e.ticker.Stop()
-	close(e.stopCh)
+	e.stopOnce.Do(func() {
+		if e.ticker != nil {
+			e.ticker.Stop()
+		}
+		close(e.stopCh)
+	})
 }
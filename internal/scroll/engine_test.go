@@ -1,6 +1,7 @@
 package scroll
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -72,3 +73,209 @@ func TestEngineEmitsDeltaWhenPlaying(t *testing.T) {
 		t.Fatal("expected at least one delta emission while playing")
 	}
 }
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestFrameEngineUsesElapsedTime(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var deltas []float64
+	engine := NewFrameEngine(func(delta float64) {
+		deltas = append(deltas, delta)
+	}, clock)
+	engine.SetEasing(EasingNone, 0)
+	engine.SetSpeed(100)
+
+	engine.Advance()
+	clock.Add(time.Second)
+	engine.Advance()
+	if len(deltas) != 0 {
+		t.Fatalf("expected no delta while paused, got %v", deltas)
+	}
+
+	engine.Play()
+	clock.Add(16 * time.Millisecond)
+	engine.Advance()
+	clock.Add(50 * time.Millisecond)
+	engine.Advance()
+	clock.Add(10 * time.Second)
+	engine.Advance()
+
+	want := []float64{1.6, 5, 100 * MaxFrameDelta.Seconds()}
+	if len(deltas) != len(want) {
+		t.Fatalf("expected %d deltas, got %v", len(want), deltas)
+	}
+	for i := range want {
+		if math.Abs(deltas[i]-want[i]) > 1e-9 {
+			t.Fatalf("delta %d: expected %v, got %v", i, want[i], deltas[i])
+		}
+	}
+}
+
+func TestFrameEngineEasesSpeedChanges(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	engine := NewFrameEngine(func(float64) {}, clock)
+	engine.SetEasing(EasingLinear, time.Second)
+	engine.SetSpeed(100)
+
+	engine.Play()
+	clock.Add(500 * time.Millisecond)
+	if got := engine.Velocity(); math.Abs(got-50) > 1e-9 {
+		t.Fatalf("expected half-way velocity 50, got %v", got)
+	}
+
+	engine.Pause()
+	if got := engine.StoppingDistance(); math.Abs(got-25) > 1e-9 {
+		t.Fatalf("expected stopping distance 25, got %v", got)
+	}
+	clock.Add(500 * time.Millisecond)
+	if got := engine.Velocity(); math.Abs(got-25) > 1e-9 {
+		t.Fatalf("expected decelerating velocity 25, got %v", got)
+	}
+	clock.Add(time.Second)
+	if got := engine.Velocity(); got != 0 {
+		t.Fatalf("expected engine to come to rest, got %v", got)
+	}
+
+	engine.SetEasing(EasingEaseInOut, 200*time.Millisecond)
+	engine.Play()
+	clock.Add(100 * time.Millisecond)
+	if got := engine.Velocity(); math.Abs(got-50) > 1e-9 {
+		t.Fatalf("expected ease-in-out midpoint 50, got %v", got)
+	}
+	engine.PauseImmediately()
+	if got := engine.Velocity(); got != 0 {
+		t.Fatalf("expected immediate stop, got %v", got)
+	}
+}
+
+func TestFrameEnginePausesWithinDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		easing   Easing
+		distance float64
+		want     float64
+	}{
+		{name: "linear", easing: EasingLinear, distance: 20, want: 20},
+		{name: "ease in out", easing: EasingEaseInOut, distance: 35, want: 35},
+		{name: "beyond the ramp", easing: EasingLinear, distance: 80, want: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			var total float64
+			engine := NewFrameEngine(func(delta float64) {
+				total += delta
+			}, clock)
+			engine.SetEasing(tt.easing, time.Second)
+			engine.SetSpeed(100)
+			engine.Play()
+			clock.Add(2 * time.Second)
+
+			engine.Advance()
+			total = 0
+			engine.PauseWithin(tt.distance)
+			for step := 0; step < 200; step++ {
+				clock.Add(5 * time.Millisecond)
+				engine.Advance()
+			}
+			if math.Abs(total-tt.want) > 0.05 {
+				t.Fatalf("stopped after %v, want %v", total, tt.want)
+			}
+			if got := engine.Velocity(); got != 0 {
+				t.Fatalf("expected engine to come to rest, got %v", got)
+			}
+		})
+	}
+}
+
+func TestFrameEngineReverse(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var total float64
+	engine := NewFrameEngine(func(delta float64) {
+		total += delta
+	}, clock)
+	engine.SetEasing(EasingNone, 0)
+	engine.SetSpeed(100)
+
+	if !engine.ToggleReverse() {
+		t.Fatal("expected reverse mode after toggle")
+	}
+	engine.Play()
+	clock.Add(100 * time.Millisecond)
+	engine.Advance()
+	if math.Abs(total+10) > 1e-9 {
+		t.Fatalf("expected reverse delta of -10, got %v", total)
+	}
+	if got := engine.StoppingDistance(); got != 0 {
+		t.Fatalf("expected no stopping distance without easing, got %v", got)
+	}
+
+	engine.SetReverse(false)
+	clock.Add(100 * time.Millisecond)
+	engine.Advance()
+	if math.Abs(total) > 1e-9 {
+		t.Fatalf("expected forward delta to cancel out, got %v", total)
+	}
+}
+
+func TestEngineAppliesCues(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	engine := NewFrameEngine(func(float64) {}, clock)
+	engine.SetEasing(EasingNone, 0)
+	engine.SetCues([]Cue{
+		{Offset: 300, Kind: CueWait, Wait: 2 * time.Second},
+		{Offset: 100, Kind: CueSpeed, Speed: 80},
+		{Offset: 200, Kind: CuePause},
+	})
+	var applied []CueKind
+	engine.SetOnCue(func(cue Cue) {
+		applied = append(applied, cue.Kind)
+	})
+
+	engine.Play()
+	engine.CrossCues(0, 150)
+	if got := engine.Speed(); got != 80 {
+		t.Fatalf("expected speed cue to set 80, got %v", got)
+	}
+	engine.CrossCues(150, 140)
+	engine.CrossCues(150, 250)
+	if engine.IsPlaying() {
+		t.Fatal("expected pause cue to stop playback")
+	}
+
+	engine.Play()
+	engine.CrossCues(250, 300)
+	if engine.IsPlaying() {
+		t.Fatal("expected wait cue to hold playback")
+	}
+	clock.Add(time.Second)
+	engine.Advance()
+	if engine.IsPlaying() {
+		t.Fatal("expected playback to stay held during the wait")
+	}
+	clock.Add(time.Second)
+	engine.Advance()
+	if !engine.IsPlaying() {
+		t.Fatal("expected playback to resume after the wait")
+	}
+
+	want := []CueKind{CueSpeed, CuePause, CueWait}
+	if len(applied) != len(want) {
+		t.Fatalf("expected cues %v, got %v", want, applied)
+	}
+	for i := range want {
+		if applied[i] != want[i] {
+			t.Fatalf("expected cues %v, got %v", want, applied)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package scroll
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ReplanInterval = 5 * time.Second
+	NudgeHold      = 15 * time.Second
+)
+
+const ArrivalTolerance = 0.01
+
+var ErrInvalidDuration = errors.New("invalid duration")
+
+type Schedule struct {
+	target    time.Duration
+	started   time.Time
+	lastPlan  time.Time
+	heldUntil time.Time
+}
+
+func NewSchedule(target time.Duration) *Schedule {
+	return &Schedule{target: target}
+}
+
+func ParseTargetDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("%w: empty", ErrInvalidDuration)
+	}
+
+	if !strings.Contains(value, ":") {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+		}
+		return parsed, nil
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+	}
+	total := 0
+	for i, part := range parts {
+		parsed, err := strconv.Atoi(part)
+		if err != nil || parsed < 0 || (i > 0 && parsed >= 60) {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+		}
+		total = total*60 + parsed
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, value)
+	}
+	return time.Duration(total) * time.Second, nil
+}
+
+func FormatClock(duration time.Duration) string {
+	sign := ""
+	if duration < 0 {
+		sign = "-"
+		duration = -duration
+	}
+	seconds := int(duration.Round(time.Second) / time.Second)
+	if seconds >= 3600 {
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, seconds/60, seconds%60)
+}
+
+func (s *Schedule) Target() time.Duration {
+	return s.target
+}
+
+func (s *Schedule) Started() bool {
+	return !s.started.IsZero()
+}
+
+func (s *Schedule) Start(now time.Time) {
+	if s.started.IsZero() {
+		s.started = now
+	}
+}
+
+func (s *Schedule) Reset() {
+	s.started = time.Time{}
+	s.lastPlan = time.Time{}
+	s.heldUntil = time.Time{}
+}
+
+func (s *Schedule) Remaining(now time.Time) time.Duration {
+	if s.started.IsZero() {
+		return s.target
+	}
+	return s.target - now.Sub(s.started)
+}
+
+func (s *Schedule) Replan() {
+	s.lastPlan = time.Time{}
+	s.heldUntil = time.Time{}
+}
+
+func (s *Schedule) Nudge(now time.Time) {
+	s.lastPlan = now
+	s.heldUntil = now.Add(NudgeHold)
+}
+
+func (s *Schedule) NeedsPlan(now time.Time) bool {
+	if now.Before(s.heldUntil) {
+		return false
+	}
+	return s.lastPlan.IsZero() || now.Sub(s.lastPlan) >= ReplanInterval
+}
+
+func (s *Schedule) Required(now time.Time, position, end float64) float64 {
+	distance := end - position
+	if distance <= 0 {
+		return 0
+	}
+	remaining := s.Remaining(now)
+	if remaining <= 0 {
+		return math.Inf(1)
+	}
+	return distance / remaining.Seconds()
+}
+
+func (s *Schedule) Plan(now time.Time, position, end float64, settle time.Duration) float64 {
+	s.lastPlan = now
+	return math.Min(s.Required(now.Add(settle), position, end), DefaultMaxSpeed)
+}
+
+func (s *Schedule) Drift(now time.Time, position, end, speed float64) time.Duration {
+	if end <= 0 || speed <= 0 || s.target <= 0 {
+		return 0
+	}
+	elapsed := s.target - s.Remaining(now)
+	expected := math.Min(end*elapsed.Seconds()/s.target.Seconds(), end)
+	return time.Duration((position - expected) / speed * float64(time.Second))
+}
+
+func SpeedInRange(speed float64) bool {
+	return speed == 0 || speed >= DefaultMinSpeed && speed <= DefaultMaxSpeed
+}
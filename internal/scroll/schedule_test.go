@@ -0,0 +1,189 @@
+package scroll
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseTargetDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "3:30", want: 210 * time.Second},
+		{value: "1:02:03", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{value: "90s", want: 90 * time.Second},
+		{value: "0:00", wantErr: true},
+		{value: "3:75", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTargetDuration(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("%q: expected an error, got %v", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", tt.value, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%q: expected %v, got %v", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestScheduleAbsorbsPauses(t *testing.T) {
+	start := time.Unix(0, 0)
+	schedule := NewSchedule(100 * time.Second)
+	schedule.Start(start)
+
+	if got := schedule.Plan(start, 0, 5000, 0); math.Abs(got-50) > 1e-9 {
+		t.Fatalf("expected 50 px/s, got %v", got)
+	}
+
+	paused := start.Add(50 * time.Second)
+	if drift := schedule.Drift(paused, 1000, 5000, 50); drift != -30*time.Second {
+		t.Fatalf("expected to be 30s behind, got %v", drift)
+	}
+	if schedule.NeedsPlan(start.Add(time.Second)) {
+		t.Fatal("expected no replan right after planning")
+	}
+	if got := schedule.Plan(paused, 1000, 5000, 0); math.Abs(got-80) > 1e-9 {
+		t.Fatalf("expected 80 px/s to catch up, got %v", got)
+	}
+	if got := schedule.Plan(start.Add(200*time.Second), 4000, 5000, 0); got != DefaultMaxSpeed {
+		t.Fatalf("expected max speed after the deadline, got %v", got)
+	}
+}
+
+func TestScheduleKeepsManualNudges(t *testing.T) {
+	start := time.Unix(0, 0)
+	schedule := NewSchedule(100 * time.Second)
+	schedule.Start(start)
+	schedule.Plan(start, 0, 5000, 0)
+
+	nudged := start.Add(4 * time.Second)
+	schedule.Nudge(nudged)
+	if schedule.NeedsPlan(nudged.Add(ReplanInterval)) {
+		t.Fatal("expected the nudged speed to hold past the replan interval")
+	}
+	if !schedule.NeedsPlan(nudged.Add(NudgeHold)) {
+		t.Fatal("expected a replan once the nudge hold is over")
+	}
+	if got := schedule.Plan(start.Add(50*time.Second), 3000, 5000, 0); math.Abs(got-40) > 1e-9 {
+		t.Fatalf("expected the plan to start from the nudged position, got %v", got)
+	}
+
+	schedule.Nudge(start.Add(60 * time.Second))
+	schedule.Replan()
+	if !schedule.NeedsPlan(start.Add(61 * time.Second)) {
+		t.Fatal("expected play to replan right away")
+	}
+}
+
+func TestScheduleRequiredSpeed(t *testing.T) {
+	start := time.Unix(0, 0)
+
+	tests := []struct {
+		name     string
+		started  bool
+		now      time.Time
+		position float64
+		want     float64
+		inRange  bool
+	}{
+		{name: "before start", now: start.Add(5 * time.Second), position: 0, want: 400, inRange: false},
+		{name: "in range", started: true, now: start.Add(5 * time.Second), position: 3500, want: 100, inRange: true},
+		{name: "too slow", started: true, now: start.Add(5 * time.Second), position: 3950, want: 10, inRange: false},
+		{name: "done", started: true, now: start.Add(5 * time.Second), position: 4000, want: 0, inRange: true},
+		{name: "late", started: true, now: start.Add(20 * time.Second), position: 3000, want: math.Inf(1), inRange: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := NewSchedule(10 * time.Second)
+			if tt.started {
+				schedule.Start(start)
+			}
+			got := schedule.Required(tt.now, tt.position, 4000)
+			if got != tt.want && math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("Required = %v, want %v", got, tt.want)
+			}
+			if SpeedInRange(got) != tt.inRange {
+				t.Fatalf("SpeedInRange(%v) = %v, want %v", got, !tt.inRange, tt.inRange)
+			}
+		})
+	}
+}
+
+func TestScheduleDriftStopsAtTheEnd(t *testing.T) {
+	start := time.Unix(0, 0)
+	schedule := NewSchedule(100 * time.Second)
+	schedule.Start(start)
+
+	if drift := schedule.Drift(start.Add(120*time.Second), 5000, 5000, 50); drift != 0 {
+		t.Fatalf("expected to be on time at the end of the scroll, got %v", drift)
+	}
+	if drift := schedule.Drift(start.Add(40*time.Second), 1500, 5000, 50); drift != -10*time.Second {
+		t.Fatalf("expected to be 10s behind, got %v", drift)
+	}
+	if drift := schedule.Drift(start.Add(102*time.Second), 4900, 5000, 50); drift != -2*time.Second {
+		t.Fatalf("expected to be 2s behind after the target, got %v", drift)
+	}
+}
+
+func TestScheduleArrivesOnTime(t *testing.T) {
+	tests := []struct {
+		name   string
+		easing Easing
+		target time.Duration
+		end    float64
+	}{
+		{name: "no easing", easing: EasingNone, target: time.Minute, end: 6000},
+		{name: "linear", easing: EasingLinear, target: 90 * time.Second, end: 4000},
+		{name: "ease in out", easing: EasingEaseInOut, target: 3 * time.Minute, end: 20000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			position := 0.0
+			var engine *Engine
+			engine = NewFrameEngine(func(delta float64) {
+				next := position + delta
+				if engine.IsPlaying() && tt.end-next <= engine.StoppingDistance() {
+					engine.PauseWithin(tt.end - next)
+				}
+				if delta > 0 && next >= tt.end-ArrivalTolerance {
+					next = tt.end
+					engine.PauseImmediately()
+				}
+				position = next
+			}, clock)
+			engine.SetEasing(tt.easing, DefaultRampDuration)
+
+			start := clock.Now()
+			schedule := NewSchedule(tt.target)
+			schedule.Start(start)
+			engine.Play()
+			for position < tt.end && clock.Now().Sub(start) < 2*tt.target {
+				if engine.IsPlaying() && schedule.NeedsPlan(clock.Now()) {
+					engine.SetSpeed(schedule.Plan(clock.Now(), position, tt.end, engine.StoppingTime()))
+				}
+				clock.Add(DefaultTickRate)
+				engine.Advance()
+			}
+
+			arrival := clock.Now().Sub(start)
+			if diff := arrival - tt.target; diff < -DefaultTickRate || diff > DefaultTickRate {
+				t.Fatalf("reached the end after %v, want %v", arrival, tt.target)
+			}
+			if drift := schedule.Drift(clock.Now(), position, tt.end, engine.Speed()); drift.Abs() > DefaultTickRate {
+				t.Fatalf("drift at the end = %v, want on time", drift)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package scroll
+
+type SpeedUnit string
+
+const (
+	UnitPixels SpeedUnit = "px"
+	UnitWPM    SpeedUnit = "wpm"
+
+	DefaultWPM = 150.0
+	MinWPM     = 60.0
+	MaxWPM     = 400.0
+	WPMStep    = 10.0
+)
+
+func ParseSpeedUnit(value string) (SpeedUnit, bool) {
+	switch SpeedUnit(value) {
+	case UnitPixels, UnitWPM:
+		return SpeedUnit(value), true
+	default:
+		return "", false
+	}
+}
+
+func ClampWPM(wpm float64) float64 {
+	if wpm < MinWPM {
+		return MinWPM
+	}
+	if wpm > MaxWPM {
+		return MaxWPM
+	}
+	return wpm
+}
+
+func WPMToSpeed(wpm float64, words int, contentHeight float32) (float64, bool) {
+	if words <= 0 || contentHeight <= 0 {
+		return 0, false
+	}
+	pixelsPerWord := float64(contentHeight) / float64(words)
+	return wpm / 60 * pixelsPerWord, true
+}
+
+func SpeedToWPM(speed float64, words int, contentHeight float32) (float64, bool) {
+	if words <= 0 || contentHeight <= 0 {
+		return 0, false
+	}
+	pixelsPerWord := float64(contentHeight) / float64(words)
+	return speed * 60 / pixelsPerWord, true
+}
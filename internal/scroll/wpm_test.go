@@ -0,0 +1,28 @@
+package scroll
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWPMToSpeed(t *testing.T) {
+	speed, ok := WPMToSpeed(120, 600, 3000)
+	if !ok {
+		t.Fatal("expected a conversion")
+	}
+	if math.Abs(speed-10) > 1e-9 {
+		t.Fatalf("expected 10 px/s, got %v", speed)
+	}
+
+	wpm, ok := SpeedToWPM(speed, 600, 3000)
+	if !ok || math.Abs(wpm-120) > 1e-9 {
+		t.Fatalf("expected round trip to 120 wpm, got %v (ok=%v)", wpm, ok)
+	}
+
+	if _, ok := WPMToSpeed(120, 0, 3000); ok {
+		t.Fatal("expected no conversion without words")
+	}
+	if got, _ := WPMToSpeed(1000, 600, 3000); math.Abs(got-1000.0/12) > 1e-9 {
+		t.Fatalf("expected an unclamped conversion, got %v px/s", got)
+	}
+}
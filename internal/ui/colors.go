@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+const ColorNameReadingMarker fyne.ThemeColorName = "grompt.reading.marker"
+
+type ColorScheme string
+
+const (
+	ColorSchemeSystem   ColorScheme = "system"
+	ColorSchemeClassic  ColorScheme = "classic"
+	ColorSchemeYellow   ColorScheme = "yellow"
+	ColorSchemeInverted ColorScheme = "inverted"
+	ColorSchemeCustom   ColorScheme = "custom"
+)
+
+var ColorSchemes = []ColorScheme{
+	ColorSchemeSystem,
+	ColorSchemeClassic,
+	ColorSchemeYellow,
+	ColorSchemeInverted,
+	ColorSchemeCustom,
+}
+
+type palette struct {
+	variant    fyne.ThemeVariant
+	foreground color.Color
+	background color.Color
+	marker     color.Color
+}
+
+var palettes = map[ColorScheme]palette{
+	ColorSchemeClassic: {
+		variant:    theme.VariantDark,
+		foreground: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		background: color.NRGBA{A: 0xff},
+		marker:     color.NRGBA{R: 0xff, G: 0xcc, A: 0xff},
+	},
+	ColorSchemeYellow: {
+		variant:    theme.VariantDark,
+		foreground: color.NRGBA{R: 0xff, G: 0xe6, B: 0x00, A: 0xff},
+		background: color.NRGBA{A: 0xff},
+		marker:     color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	},
+	ColorSchemeInverted: {
+		variant:    theme.VariantLight,
+		foreground: color.NRGBA{A: 0xff},
+		background: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		marker:     color.NRGBA{R: 0xc0, A: 0xff},
+	},
+	ColorSchemeCustom: {
+		variant:    theme.VariantDark,
+		foreground: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		background: color.NRGBA{A: 0xff},
+	},
+}
+
+func ParseColorScheme(value string) (ColorScheme, bool) {
+	scheme := ColorScheme(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range ColorSchemes {
+		if scheme == known {
+			return scheme, true
+		}
+	}
+	return "", false
+}
+
+func formatColorScheme(scheme ColorScheme) string {
+	switch scheme {
+	case ColorSchemeClassic:
+		return "White on black"
+	case ColorSchemeYellow:
+		return "Yellow on black"
+	case ColorSchemeInverted:
+		return "Black on white"
+	case ColorSchemeCustom:
+		return "Custom"
+	default:
+		return "System"
+	}
+}
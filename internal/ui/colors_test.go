@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestParseColorScheme(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ColorScheme
+		ok    bool
+	}{
+		{value: "system", want: ColorSchemeSystem, ok: true},
+		{value: "classic", want: ColorSchemeClassic, ok: true},
+		{value: " Yellow ", want: ColorSchemeYellow, ok: true},
+		{value: "INVERTED", want: ColorSchemeInverted, ok: true},
+		{value: "custom", want: ColorSchemeCustom, ok: true},
+		{value: "", ok: false},
+		{value: "sepia", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ParseColorScheme(tt.value)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("ParseColorScheme(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTypographyThemeColor(t *testing.T) {
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	base := theme.DefaultTheme()
+
+	tests := []struct {
+		name       string
+		scheme     ColorScheme
+		foreground color.Color
+		background color.Color
+		variant    fyne.ThemeVariant
+		text       color.Color
+		fill       color.Color
+		marker     color.Color
+	}{
+		{
+			name:    "system dark",
+			scheme:  ColorSchemeSystem,
+			variant: theme.VariantDark,
+			text:    base.Color(theme.ColorNameForeground, theme.VariantDark),
+			fill:    base.Color(theme.ColorNameBackground, theme.VariantDark),
+			marker:  base.Color(theme.ColorNameForeground, theme.VariantDark),
+		},
+		{
+			name:    "system light",
+			scheme:  ColorSchemeSystem,
+			variant: theme.VariantLight,
+			text:    base.Color(theme.ColorNameForeground, theme.VariantLight),
+			fill:    base.Color(theme.ColorNameBackground, theme.VariantLight),
+			marker:  base.Color(theme.ColorNameForeground, theme.VariantLight),
+		},
+		{
+			name:       "classic ignores custom colours",
+			scheme:     ColorSchemeClassic,
+			foreground: red,
+			background: blue,
+			variant:    theme.VariantLight,
+			text:       palettes[ColorSchemeClassic].foreground,
+			fill:       palettes[ColorSchemeClassic].background,
+			marker:     palettes[ColorSchemeClassic].marker,
+		},
+		{
+			name:    "yellow",
+			scheme:  ColorSchemeYellow,
+			variant: theme.VariantLight,
+			text:    palettes[ColorSchemeYellow].foreground,
+			fill:    palettes[ColorSchemeYellow].background,
+			marker:  palettes[ColorSchemeYellow].marker,
+		},
+		{
+			name:       "inverted ignores custom colours",
+			scheme:     ColorSchemeInverted,
+			foreground: red,
+			variant:    theme.VariantDark,
+			text:       palettes[ColorSchemeInverted].foreground,
+			fill:       palettes[ColorSchemeInverted].background,
+			marker:     palettes[ColorSchemeInverted].marker,
+		},
+		{
+			name:       "custom",
+			scheme:     ColorSchemeCustom,
+			foreground: red,
+			background: blue,
+			variant:    theme.VariantLight,
+			text:       red,
+			fill:       blue,
+			marker:     red,
+		},
+		{
+			name:       "custom foreground only",
+			scheme:     ColorSchemeCustom,
+			foreground: red,
+			variant:    theme.VariantLight,
+			text:       red,
+			fill:       palettes[ColorSchemeCustom].background,
+			marker:     red,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typography := NewTypographyTheme(DefaultContentFontSize)
+			typography.SetColors(tt.scheme, tt.foreground, tt.background)
+
+			if got := typography.Color(theme.ColorNameForeground, tt.variant); got != tt.text {
+				t.Fatalf("foreground = %v, want %v", got, tt.text)
+			}
+			if got := typography.Color(theme.ColorNameBackground, tt.variant); got != tt.fill {
+				t.Fatalf("background = %v, want %v", got, tt.fill)
+			}
+			if got := typography.Color(ColorNameReadingMarker, tt.variant); got != tt.marker {
+				t.Fatalf("reading marker = %v, want %v", got, tt.marker)
+			}
+		})
+	}
+}
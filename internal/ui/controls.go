@@ -2,12 +2,15 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	scrollengine "grompt/internal/scroll"
 )
 
 type ControlActions struct {
@@ -16,33 +19,48 @@ type ControlActions struct {
 	OnPause     func()
 	OnSpeedUp   func()
 	OnSpeedDown func()
+	OnRewind    func()
+	OnReverse   func()
 }
 
 type Controls struct {
 	root           fyne.CanvasObject
 	fileLabel      *widget.Label
+	scheduleLabel  *widget.Label
 	speedLabel     *widget.Label
 	settingsButton *widget.Button
+	reverseButton  *widget.Button
+	speed          float64
+	effective      float64
+	unit           scrollengine.SpeedUnit
+	reverse        bool
 }
 
-func NewControls(actions ControlActions, initialSpeed float64) *Controls {
+func NewControls(actions ControlActions, initialSpeed float64, unit scrollengine.SpeedUnit) *Controls {
 	fileLabel := widget.NewLabel("No file loaded")
-	speedLabel := widget.NewLabel(formatSpeed(initialSpeed))
+	scheduleLabel := widget.NewLabel("")
+	scheduleLabel.Hide()
+	speedLabel := widget.NewLabel(formatSpeed(initialSpeed, 0, unit))
 
 	settingsButton := widget.NewButtonWithIcon("", theme.MenuIcon(), actions.OnSettings)
 	playButton := widget.NewButton("Play", actions.OnPlay)
 	pauseButton := widget.NewButton("Pause", actions.OnPause)
 	speedUpButton := widget.NewButton("Speed +", actions.OnSpeedUp)
 	speedDownButton := widget.NewButton("Speed -", actions.OnSpeedDown)
+	rewindButton := widget.NewButton("Rewind", actions.OnRewind)
+	reverseButton := widget.NewButton("Reverse", actions.OnReverse)
 
 	root := container.NewHBox(
 		settingsButton,
 		layout.NewSpacer(),
 		fileLabel,
+		scheduleLabel,
 		layout.NewSpacer(),
 		speedDownButton,
 		speedLabel,
 		speedUpButton,
+		rewindButton,
+		reverseButton,
 		playButton,
 		pauseButton,
 	)
@@ -50,8 +68,12 @@ func NewControls(actions ControlActions, initialSpeed float64) *Controls {
 	return &Controls{
 		root:           root,
 		fileLabel:      fileLabel,
+		scheduleLabel:  scheduleLabel,
 		speedLabel:     speedLabel,
 		settingsButton: settingsButton,
+		reverseButton:  reverseButton,
+		speed:          initialSpeed,
+		unit:           unit,
 	}
 }
 
@@ -63,14 +85,105 @@ func (c *Controls) SetFileName(name string) {
 	c.fileLabel.SetText(name)
 }
 
+func (c *Controls) SetSchedule(status string) {
+	if status == "" {
+		c.scheduleLabel.Hide()
+		return
+	}
+	if c.scheduleLabel.Text != status {
+		c.scheduleLabel.SetText(status)
+	}
+	c.scheduleLabel.Show()
+}
+
 func (c *Controls) SetSpeed(speed float64) {
-	c.speedLabel.SetText(formatSpeed(speed))
+	c.speed = speed
+	c.refreshSpeed()
+}
+
+func (c *Controls) SetEffectiveSpeed(speed float64) {
+	if c.effective == speed {
+		return
+	}
+	c.effective = speed
+	c.refreshSpeed()
+}
+
+func (c *Controls) SetSpeedUnit(speed float64, unit scrollengine.SpeedUnit) {
+	c.speed = speed
+	c.unit = unit
+	c.refreshSpeed()
+}
+
+func (c *Controls) SetReverse(reverse bool) {
+	c.reverse = reverse
+	if reverse {
+		c.reverseButton.Importance = widget.HighImportance
+	} else {
+		c.reverseButton.Importance = widget.MediumImportance
+	}
+	c.reverseButton.Refresh()
+	c.refreshSpeed()
+}
+
+func (c *Controls) refreshSpeed() {
+	speed, effective := c.speed, c.effective
+	if c.reverse {
+		speed, effective = -speed, -effective
+	}
+	c.speedLabel.SetText(formatSpeed(speed, effective, c.unit))
 }
 
 func (c *Controls) SettingsAnchor() fyne.CanvasObject {
 	return c.settingsButton
 }
 
-func formatSpeed(speed float64) string {
+func formatSpeed(speed, effective float64, unit scrollengine.SpeedUnit) string {
+	if unit == scrollengine.UnitWPM && effective != 0 && math.Abs(effective-speed) >= 0.5 {
+		return fmt.Sprintf("%.0f wpm (%.0f effective)", speed, effective)
+	}
+	if unit == scrollengine.UnitWPM {
+		return fmt.Sprintf("%.0f wpm", speed)
+	}
 	return fmt.Sprintf("%.0f px/s", speed)
 }
+
+func formatSpeedUnit(unit scrollengine.SpeedUnit) string {
+	if unit == scrollengine.UnitWPM {
+		return "words per minute"
+	}
+	return "px/s"
+}
+
+func formatToggle(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+func formatSchedule(remaining, drift time.Duration, started bool, required float64) string {
+	status := "Target " + scrollengine.FormatClock(remaining)
+	if started {
+		status = scrollengine.FormatClock(remaining) + " left"
+	}
+	switch {
+	case math.IsInf(required, 1):
+		return status + ", out of time"
+	case !started:
+	case drift >= time.Second:
+		status += ", " + scrollengine.FormatClock(drift) + " ahead"
+	case drift <= -time.Second:
+		status += ", " + scrollengine.FormatClock(-drift) + " behind"
+	default:
+		status += ", on time"
+	}
+	switch {
+	case scrollengine.SpeedInRange(required):
+		return status
+	case required > scrollengine.DefaultMaxSpeed:
+		return fmt.Sprintf("%s, needs %.0f px/s (max %.0f)", status, required, scrollengine.DefaultMaxSpeed)
+	default:
+		return fmt.Sprintf("%s, needs %.0f px/s (min %.0f)", status, required, scrollengine.DefaultMinSpeed)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	scrollengine "grompt/internal/scroll"
+)
+
+func TestFormatSchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining time.Duration
+		drift     time.Duration
+		started   bool
+		required  float64
+		want      string
+	}{
+		{name: "not started", remaining: 210 * time.Second, required: 80, want: "Target 3:30"},
+		{name: "on time", remaining: 90 * time.Second, started: true, required: 80, want: "1:30 left, on time"},
+		{name: "ahead", remaining: 90 * time.Second, drift: 5 * time.Second, started: true, required: 80, want: "1:30 left, 0:05 ahead"},
+		{name: "behind", remaining: 90 * time.Second, drift: -5 * time.Second, started: true, required: 80, want: "1:30 left, 0:05 behind"},
+		{name: "too fast", remaining: 30 * time.Second, required: 450, want: "Target 0:30, needs 450 px/s (max 300)"},
+		{name: "too slow", remaining: 90 * time.Second, started: true, required: 12, want: "1:30 left, on time, needs 12 px/s (min 20)"},
+		{name: "out of time", remaining: -2 * time.Second, started: true, required: math.Inf(1), want: "-0:02 left, out of time"},
+		{name: "out of time and behind", remaining: -2 * time.Second, drift: -7 * time.Second, started: true, required: math.Inf(1), want: "-0:02 left, out of time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSchedule(tt.remaining, tt.drift, tt.started, tt.required); got != tt.want {
+				t.Fatalf("formatSchedule = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSpeed(t *testing.T) {
+	tests := []struct {
+		name      string
+		speed     float64
+		effective float64
+		unit      scrollengine.SpeedUnit
+		want      string
+	}{
+		{name: "pixels", speed: 60, effective: 120, unit: scrollengine.UnitPixels, want: "60 px/s"},
+		{name: "wpm", speed: 150, unit: scrollengine.UnitWPM, want: "150 wpm"},
+		{name: "wpm reached", speed: 150, effective: 150.2, unit: scrollengine.UnitWPM, want: "150 wpm"},
+		{name: "wpm clamped", speed: 120, effective: 240, unit: scrollengine.UnitWPM, want: "120 wpm (240 effective)"},
+		{name: "reverse", speed: -120, effective: -240, unit: scrollengine.UnitWPM, want: "-120 wpm (-240 effective)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSpeed(tt.speed, tt.effective, tt.unit); got != tt.want {
+				t.Fatalf("formatSpeed = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
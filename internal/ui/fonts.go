@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"grompt/assets"
+)
+
+const DefaultFontName = "default"
+
+var errNotAFont = errors.New("not a TrueType or OpenType font")
+
+var fontStemSuffixes = []string{"-Regular", "_Regular", " Regular", "Regular"}
+
+type fontFamily struct {
+	name       string
+	regular    fyne.Resource
+	bold       fyne.Resource
+	italic     fyne.Resource
+	boldItalic fyne.Resource
+	monospace  fyne.Resource
+}
+
+func defaultFontFamily() fontFamily {
+	return fontFamily{name: DefaultFontName}
+}
+
+func loadFontFamily(name string) (fontFamily, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, DefaultFontName) {
+		return defaultFontFamily(), nil
+	}
+	if bundled, ok := assets.LookupFontFamily(strings.ToLower(name)); ok {
+		return fontFamily{
+			name:       bundled.Name,
+			regular:    bundled.Regular,
+			bold:       bundled.Bold,
+			italic:     bundled.Italic,
+			boldItalic: bundled.BoldItalic,
+			monospace:  bundled.Monospace,
+		}, nil
+	}
+
+	path := name
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fontFamily{}, err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	regular, err := readFontFile(path)
+	if err != nil {
+		return fontFamily{}, err
+	}
+
+	family := fontFamily{name: name, regular: regular, bold: regular, italic: regular, boldItalic: regular, monospace: regular}
+	ext := filepath.Ext(path)
+	stem, separator := strings.TrimSuffix(path, ext), "-"
+	for _, suffix := range fontStemSuffixes {
+		if strings.HasSuffix(stem, suffix) {
+			stem = strings.TrimSuffix(stem, suffix)
+			separator = strings.TrimSuffix(suffix, "Regular")
+			break
+		}
+	}
+	variant := func(fallback fyne.Resource, names ...string) fyne.Resource {
+		for _, variantName := range names {
+			if resource, err := readFontFile(stem + separator + variantName + ext); err == nil {
+				return resource
+			}
+		}
+		return fallback
+	}
+	family.bold = variant(regular, "Bold")
+	family.italic = variant(regular, "Italic", "Oblique")
+	family.boldItalic = variant(family.bold, "BoldItalic", "Bold"+separator+"Italic", "BoldOblique", "Bold"+separator+"Oblique")
+	return family, nil
+}
+
+func readFontFile(path string) (fyne.Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isFontData(data) {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), errNotAFont)
+	}
+	return fyne.NewStaticResource(path, data), nil
+}
+
+func isFontData(data []byte) bool {
+	if len(data) < 12 {
+		return false
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "OTTO", "true":
+		return true
+	default:
+		return false
+	}
+}
+
+func (f fontFamily) font(style fyne.TextStyle) fyne.Resource {
+	switch {
+	case style.Symbol:
+		return nil
+	case style.Monospace:
+		return f.monospace
+	case style.Bold && style.Italic:
+		return f.boldItalic
+	case style.Bold:
+		return f.bold
+	case style.Italic:
+		return f.italic
+	default:
+		return f.regular
+	}
+}
+
+func formatFontFamily(name string) string {
+	if name == "" || strings.EqualFold(name, DefaultFontName) {
+		return "Default"
+	}
+	if bundled, ok := assets.LookupFontFamily(strings.ToLower(name)); ok {
+		return bundled.Title
+	}
+	return filepath.Base(name)
+}
+
+func fontChoices(current string) []string {
+	choices := []string{DefaultFontName}
+	known := strings.EqualFold(current, DefaultFontName)
+	for _, family := range assets.FontFamilies() {
+		choices = append(choices, family.Name)
+		known = known || strings.EqualFold(current, family.Name)
+	}
+	if !known && current != "" {
+		choices = append(choices, current)
+	}
+	return choices
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	appconfig "grompt/internal/config"
+)
+
+var testFontData = []byte{0x00, 0x01, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+
+func writeTestFonts(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), testFontData, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIsFontData(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{name: "truetype", data: testFontData, want: true},
+		{name: "opentype", data: []byte("OTTO\x00\x00\x00\x00\x00\x00\x00\x00"), want: true},
+		{name: "apple truetype", data: []byte("true\x00\x00\x00\x00\x00\x00\x00\x00"), want: true},
+		{name: "collection", data: []byte("ttcf\x00\x00\x00\x00\x00\x00\x00\x00"), want: false},
+		{name: "text", data: []byte("not a font file at all"), want: false},
+		{name: "short", data: []byte("OTTO"), want: false},
+		{name: "empty", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFontData(tt.data); got != tt.want {
+				t.Fatalf("isFontData = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFontFamilyFindsVariants(t *testing.T) {
+	resourceName := func(resource fyne.Resource) string {
+		if resource == nil {
+			return ""
+		}
+		return filepath.Base(resource.Name())
+	}
+
+	tests := []struct {
+		name       string
+		files      []string
+		open       string
+		bold       string
+		italic     string
+		boldItalic string
+	}{
+		{
+			name:       "dash regular",
+			files:      []string{"Sans-Regular.ttf", "Sans-Bold.ttf", "Sans-Italic.ttf", "Sans-BoldItalic.ttf"},
+			open:       "Sans-Regular.ttf",
+			bold:       "Sans-Bold.ttf",
+			italic:     "Sans-Italic.ttf",
+			boldItalic: "Sans-BoldItalic.ttf",
+		},
+		{
+			name:       "underscore oblique",
+			files:      []string{"Sans_Regular.otf", "Sans_Bold.otf", "Sans_Oblique.otf", "Sans_Bold_Oblique.otf"},
+			open:       "Sans_Regular.otf",
+			bold:       "Sans_Bold.otf",
+			italic:     "Sans_Oblique.otf",
+			boldItalic: "Sans_Bold_Oblique.otf",
+		},
+		{
+			name:       "plain stem",
+			files:      []string{"Sans.ttf", "Sans-Bold.ttf"},
+			open:       "Sans.ttf",
+			bold:       "Sans-Bold.ttf",
+			italic:     "Sans.ttf",
+			boldItalic: "Sans-Bold.ttf",
+		},
+		{
+			name:       "single file",
+			files:      []string{"Sans-Regular.ttf"},
+			open:       "Sans-Regular.ttf",
+			bold:       "Sans-Regular.ttf",
+			italic:     "Sans-Regular.ttf",
+			boldItalic: "Sans-Regular.ttf",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFonts(t, dir, tt.files...)
+
+			family, err := loadFontFamily(filepath.Join(dir, tt.open))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := resourceName(family.regular); got != tt.open {
+				t.Fatalf("regular = %q, want %q", got, tt.open)
+			}
+			if got := resourceName(family.bold); got != tt.bold {
+				t.Fatalf("bold = %q, want %q", got, tt.bold)
+			}
+			if got := resourceName(family.italic); got != tt.italic {
+				t.Fatalf("italic = %q, want %q", got, tt.italic)
+			}
+			if got := resourceName(family.boldItalic); got != tt.boldItalic {
+				t.Fatalf("bold italic = %q, want %q", got, tt.boldItalic)
+			}
+			if got := resourceName(family.monospace); got != tt.open {
+				t.Fatalf("monospace = %q, want %q", got, tt.open)
+			}
+		})
+	}
+}
+
+func TestLoadFontFamilyRejectsNonFonts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.ttf")
+	if err := os.WriteFile(path, []byte("these are not glyphs"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadFontFamily(path); err == nil {
+		t.Fatal("expected an error for a file that is not a font")
+	}
+}
+
+func TestLoadFontFamilyBundled(t *testing.T) {
+	for _, name := range []string{"go", "GO-Medium", "go-mono", "dejavu-condensed"} {
+		family, err := loadFontFamily(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, resource := range []fyne.Resource{family.regular, family.bold, family.italic, family.boldItalic, family.monospace} {
+			if resource == nil || !isFontData(resource.Content()) {
+				t.Fatalf("%s: missing or invalid font resource", name)
+			}
+		}
+	}
+}
+
+func TestResolveSettingsKeepsUnloadableFontName(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "Missing-Regular.ttf")
+	resolved, warnings := resolveSettings(appconfig.FileSettings{Font: &name})
+	if len(warnings) != 1 {
+		t.Fatalf("warnings = %v, want one font warning", warnings)
+	}
+	if got := resolved.settings("").Font; got != name {
+		t.Fatalf("saved font = %q, want %q", got, name)
+	}
+	if resolved.font.regular != nil {
+		t.Fatal("an unloadable font should render with the default typeface")
+	}
+}
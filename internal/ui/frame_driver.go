@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	scrollengine "grompt/internal/scroll"
+)
+
+func newFrameDriver(engine *scrollengine.Engine, onFrame func()) *fyne.Animation {
+	driver := fyne.NewAnimation(time.Second, func(float32) {
+		engine.Advance()
+		if onFrame != nil {
+			onFrame()
+		}
+	})
+	driver.Curve = fyne.AnimationLinear
+	driver.RepeatCount = fyne.AnimationRepeatForever
+	return driver
+}
@@ -0,0 +1,133 @@
+package ui
+
+import "strings"
+
+const (
+	DefaultGuideLines = 3
+	MinGuideLines     = 1
+	MaxGuideLines     = 10
+	DefaultGuideFade  = 90
+	MinGuideFade      = 0
+	MaxGuideFade      = 100
+	GuideFadeStep     = 10
+)
+
+type GuidePosition string
+
+const (
+	GuidePositionCenter   GuidePosition = "center"
+	GuidePositionTopThird GuidePosition = "top_third"
+)
+
+type GuideMarker string
+
+const (
+	GuideMarkerChevrons GuideMarker = "chevrons"
+	GuideMarkerArrows   GuideMarker = "arrows"
+	GuideMarkerBar      GuideMarker = "bar"
+	GuideMarkerNone     GuideMarker = "none"
+)
+
+var GuideMarkers = []GuideMarker{
+	GuideMarkerChevrons,
+	GuideMarkerArrows,
+	GuideMarkerBar,
+	GuideMarkerNone,
+}
+
+type ReadingGuide struct {
+	Position GuidePosition
+	Lines    int
+	Fade     int
+	Marker   GuideMarker
+}
+
+func DefaultReadingGuide() ReadingGuide {
+	return ReadingGuide{
+		Position: GuidePositionCenter,
+		Lines:    DefaultGuideLines,
+		Fade:     DefaultGuideFade,
+		Marker:   GuideMarkerChevrons,
+	}
+}
+
+func (g ReadingGuide) Ratio() float32 {
+	if g.Position == GuidePositionTopThird {
+		return float32(1) / 3
+	}
+	return 0.5
+}
+
+func (g ReadingGuide) fadeAlpha() uint8 {
+	return uint8(float32(NormalizeGuideFade(g.Fade)) / 100 * 255)
+}
+
+func ParseGuidePosition(value string) (GuidePosition, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "center", "centre":
+		return GuidePositionCenter, true
+	case "top_third", "top":
+		return GuidePositionTopThird, true
+	default:
+		return "", false
+	}
+}
+
+func ParseGuideMarker(value string) (GuideMarker, bool) {
+	marker := GuideMarker(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range GuideMarkers {
+		if marker == known {
+			return marker, true
+		}
+	}
+	return "", false
+}
+
+func NormalizeGuideLines(lines int) int {
+	if lines < MinGuideLines {
+		return MinGuideLines
+	}
+	if lines > MaxGuideLines {
+		return MaxGuideLines
+	}
+	return lines
+}
+
+func NormalizeGuideFade(fade int) int {
+	if fade < MinGuideFade {
+		return MinGuideFade
+	}
+	if fade > MaxGuideFade {
+		return MaxGuideFade
+	}
+	return fade
+}
+
+func nextGuideMarker(marker GuideMarker) GuideMarker {
+	for i, known := range GuideMarkers {
+		if known == marker {
+			return GuideMarkers[(i+1)%len(GuideMarkers)]
+		}
+	}
+	return GuideMarkerChevrons
+}
+
+func formatGuidePosition(position GuidePosition) string {
+	if position == GuidePositionTopThird {
+		return "Top third"
+	}
+	return "Centre"
+}
+
+func formatGuideMarker(marker GuideMarker) string {
+	switch marker {
+	case GuideMarkerArrows:
+		return "Arrows"
+	case GuideMarkerBar:
+		return "Highlight bar"
+	case GuideMarkerNone:
+		return "None"
+	default:
+		return "Chevrons"
+	}
+}
@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	appconfig "grompt/internal/config"
+)
+
+func TestParseGuidePosition(t *testing.T) {
+	tests := []struct {
+		value string
+		want  GuidePosition
+		ok    bool
+	}{
+		{value: "center", want: GuidePositionCenter, ok: true},
+		{value: " Centre ", want: GuidePositionCenter, ok: true},
+		{value: "top_third", want: GuidePositionTopThird, ok: true},
+		{value: "TOP", want: GuidePositionTopThird, ok: true},
+		{value: "bottom", ok: false},
+		{value: "", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ParseGuidePosition(tt.value)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("ParseGuidePosition(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseGuideMarker(t *testing.T) {
+	tests := []struct {
+		value string
+		want  GuideMarker
+		ok    bool
+	}{
+		{value: "chevrons", want: GuideMarkerChevrons, ok: true},
+		{value: "Arrows", want: GuideMarkerArrows, ok: true},
+		{value: " bar ", want: GuideMarkerBar, ok: true},
+		{value: "none", want: GuideMarkerNone, ok: true},
+		{value: "chevron", ok: false},
+		{value: "", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ParseGuideMarker(tt.value)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("ParseGuideMarker(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNormalizeGuideValues(t *testing.T) {
+	lines := []struct{ in, want int }{
+		{in: -1, want: MinGuideLines},
+		{in: 0, want: MinGuideLines},
+		{in: 1, want: 1},
+		{in: 5, want: 5},
+		{in: 10, want: 10},
+		{in: 11, want: MaxGuideLines},
+	}
+	for _, tt := range lines {
+		if got := NormalizeGuideLines(tt.in); got != tt.want {
+			t.Fatalf("NormalizeGuideLines(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	fades := []struct{ in, want int }{
+		{in: -10, want: MinGuideFade},
+		{in: 0, want: 0},
+		{in: 55, want: 55},
+		{in: 100, want: 100},
+		{in: 150, want: MaxGuideFade},
+	}
+	for _, tt := range fades {
+		if got := NormalizeGuideFade(tt.in); got != tt.want {
+			t.Fatalf("NormalizeGuideFade(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNextGuideMarker(t *testing.T) {
+	tests := []struct {
+		marker GuideMarker
+		want   GuideMarker
+	}{
+		{marker: GuideMarkerChevrons, want: GuideMarkerArrows},
+		{marker: GuideMarkerArrows, want: GuideMarkerBar},
+		{marker: GuideMarkerBar, want: GuideMarkerNone},
+		{marker: GuideMarkerNone, want: GuideMarkerChevrons},
+		{marker: "unknown", want: GuideMarkerChevrons},
+	}
+	for _, tt := range tests {
+		if got := nextGuideMarker(tt.marker); got != tt.want {
+			t.Fatalf("nextGuideMarker(%q) = %q, want %q", tt.marker, got, tt.want)
+		}
+	}
+}
+
+func TestScrollFadeLayoutBand(t *testing.T) {
+	test.NewTempApp(t)
+
+	tests := []struct {
+		name      string
+		guide     ReadingGuide
+		flip      bool
+		bandTop   float32
+		bandWidth float32
+	}{
+		{name: "center", guide: ReadingGuide{Position: GuidePositionCenter, Lines: 3}, bandTop: 240, bandWidth: 120},
+		{name: "top third", guide: ReadingGuide{Position: GuidePositionTopThird, Lines: 3}, bandTop: 140, bandWidth: 120},
+		{name: "top third flipped", guide: ReadingGuide{Position: GuidePositionTopThird, Lines: 3}, flip: true, bandTop: 340, bandWidth: 120},
+		{name: "top third tall", guide: ReadingGuide{Position: GuidePositionTopThird, Lines: 8}, bandTop: 40, bandWidth: 320},
+		{name: "top third clamped", guide: ReadingGuide{Position: GuidePositionTopThird, Lines: 10}, bandTop: 0, bandWidth: 400},
+		{name: "lines normalized", guide: ReadingGuide{Position: GuidePositionTopThird, Lines: 0}, bandTop: 180, bandWidth: 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guide, flip := tt.guide, tt.flip
+			layout := &scrollFadeLayout{
+				guide:              &guide,
+				flip:               &flip,
+				lineHeightProvider: func() float32 { return 40 },
+			}
+			scroll := canvas.NewRectangle(color.Black)
+			mirrored := canvas.NewRectangle(color.Black)
+			top := canvas.NewRectangle(color.Black)
+			bottom := canvas.NewRectangle(color.Black)
+			left := canvas.NewText(">", color.White)
+			right := canvas.NewText("<", color.White)
+			highlight := canvas.NewRectangle(color.White)
+
+			size := fyne.NewSize(800, 600)
+			layout.Layout([]fyne.CanvasObject{scroll, mirrored, top, bottom, left, right, highlight}, size)
+
+			bandBottom := tt.bandTop + tt.bandWidth
+			if top.Position().Y != 0 || !nearly(top.Size().Height, tt.bandTop) {
+				t.Fatalf("top fade = %v %v, want height %v", top.Position(), top.Size(), tt.bandTop)
+			}
+			if !nearly(bottom.Position().Y, bandBottom) || !nearly(bottom.Size().Height, size.Height-bandBottom) {
+				t.Fatalf("bottom fade = %v %v, want to start at %v", bottom.Position(), bottom.Size(), bandBottom)
+			}
+			if !nearly(highlight.Position().Y, tt.bandTop) || !nearly(highlight.Size().Height, tt.bandWidth) {
+				t.Fatalf("highlight = %v %v, want %v high at %v", highlight.Position(), highlight.Size(), tt.bandWidth, tt.bandTop)
+			}
+			center := tt.bandTop + tt.bandWidth/2
+			if chevron := left.Position().Y + left.MinSize().Height/2; !nearly(chevron, center) {
+				t.Fatalf("chevron centre = %v, want %v", chevron, center)
+			}
+			if scroll.Size().Height != size.Height || mirrored.Position() != scroll.Position() || mirrored.Size() != scroll.Size() {
+				t.Fatalf("scroll %v %v and mirror %v %v should fill the height together", scroll.Position(), scroll.Size(), mirrored.Position(), mirrored.Size())
+			}
+		})
+	}
+}
+
+func nearly(got, want float32) bool {
+	return math.Abs(float64(got-want)) < 0.01
+}
+
+func TestResolveSettingsGuide(t *testing.T) {
+	position, marker := "top", "Bar"
+	lines, fade := 20, -5
+	resolved, warnings := resolveSettings(appconfig.FileSettings{
+		GuidePosition: &position,
+		GuideMarker:   &marker,
+		GuideLines:    &lines,
+		GuideFade:     &fade,
+	})
+	want := ReadingGuide{Position: GuidePositionTopThird, Lines: MaxGuideLines, Fade: MinGuideFade, Marker: GuideMarkerBar}
+	if resolved.guide != want {
+		t.Fatalf("guide = %+v, want %+v", resolved.guide, want)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected clamping warnings for lines and fade, got %v", warnings)
+	}
+
+	position, marker = "bottom", "stars"
+	resolved, warnings = resolveSettings(appconfig.FileSettings{GuidePosition: &position, GuideMarker: &marker})
+	if resolved.guide != DefaultReadingGuide() || len(warnings) != 2 {
+		t.Fatalf("expected the default guide and two warnings, got %+v %v", resolved.guide, warnings)
+	}
+	if got := resolved.settings("").GuidePosition; got != string(GuidePositionCenter) {
+		t.Fatalf("saved guide_position = %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"grompt/internal/input"
+)
+
+var actionTitles = map[input.Action]string{
+	input.ActionPlayPause:         "Play / pause",
+	input.ActionSpeedUp:           "Speed +",
+	input.ActionSpeedDown:         "Speed -",
+	input.ActionFontSizeUp:        "Text size +",
+	input.ActionFontSizeDown:      "Text size -",
+	input.ActionWordSpacingUp:     "Word spacing +",
+	input.ActionWordSpacingDown:   "Word spacing -",
+	input.ActionLineHeightUp:      "Line height +",
+	input.ActionLineHeightDown:    "Line height -",
+	input.ActionLetterSpacingUp:   "Letter spacing +",
+	input.ActionLetterSpacingDown: "Letter spacing -",
+	input.ActionMarginUp:          "Margins +",
+	input.ActionMarginDown:        "Margins -",
+	input.ActionRewind:            "Rewind",
+	input.ActionResetToTop:        "Back to top",
+	input.ActionNextSection:       "Next section",
+	input.ActionPreviousSection:   "Previous section",
+	input.ActionToggleReverse:     "Reverse direction",
+	input.ActionToggleMirror:      "Mirror horizontally",
+	input.ActionToggleFlip:        "Flip vertically",
+	input.ActionToggleTalent:      "Talent window",
+	input.ActionFullScreen:        "Toggle full screen",
+	input.ActionShowHelp:          "Keyboard shortcuts",
+}
+
+func newKeymapHelp(canvas fyne.Canvas, keymap input.Keymap) *widget.PopUp {
+	rows := make([]fyne.CanvasObject, 0, len(input.KnownActions)*2)
+	for _, action := range input.KnownActions {
+		bindings := keymap.Bindings(action)
+		names := make([]string, 0, len(bindings))
+		for _, binding := range bindings {
+			names = append(names, binding.String())
+		}
+		keys := "-"
+		if len(names) > 0 {
+			keys = strings.Join(names, ", ")
+		}
+
+		keyLabel := widget.NewLabel(keys)
+		keyLabel.TextStyle = fyne.TextStyle{Monospace: true}
+		rows = append(rows, widget.NewLabel(actionTitles[action]), keyLabel)
+	}
+
+	var popup *widget.PopUp
+	closeButton := widget.NewButton("×", func() {
+		if popup != nil {
+			popup.Hide()
+		}
+	})
+	closeButton.Importance = widget.LowImportance
+
+	header := container.NewBorder(nil, nil, nil, closeButton, widget.NewLabel("Keyboard shortcuts"))
+	list := container.NewVScroll(container.NewGridWithColumns(2, rows...))
+	popup = widget.NewModalPopUp(container.NewBorder(header, nil, nil, nil, list), canvas)
+	popup.Resize(fyne.NewSize(460, 520))
+	return popup
+}
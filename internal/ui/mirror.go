@@ -0,0 +1,461 @@
+package ui
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/go-text/render"
+	"github.com/go-text/typesetting/font"
+)
+
+type mirrorRun struct {
+	text  string
+	style fyne.TextStyle
+	size  float32
+	color color.Color
+	x     float32
+}
+
+type mirrorRow struct {
+	runs     []mirrorRun
+	rule     color.Color
+	align    fyne.TextAlign
+	position fyne.Position
+	size     fyne.Size
+	image    *canvas.Image
+}
+
+type mirrorLayer struct {
+	widget.BaseWidget
+
+	scroll     *container.Scroll
+	guide      *ReadingGuide
+	background *canvas.Rectangle
+	mirror     bool
+	flip       bool
+
+	source       fyne.CanvasObject
+	sourceHeight float32
+	width        float32
+	scale        float32
+	height       float32
+	rows         []*mirrorRow
+	objects      []fyne.CanvasObject
+	faces        map[fyne.Resource]*font.Face
+	stale        bool
+}
+
+func newMirrorLayer(scroll *container.Scroll, guide *ReadingGuide) *mirrorLayer {
+	m := &mirrorLayer{
+		scroll:     scroll,
+		guide:      guide,
+		background: canvas.NewRectangle(color.Transparent),
+		faces:      map[fyne.Resource]*font.Face{},
+		stale:      true,
+	}
+	m.objects = []fyne.CanvasObject{m.background}
+	m.ExtendBaseWidget(m)
+	m.Hide()
+	return m
+}
+
+func (m *mirrorLayer) CreateRenderer() fyne.WidgetRenderer {
+	return &mirrorRenderer{layer: m}
+}
+
+func (m *mirrorLayer) SetMirror(mirror, flip bool) {
+	m.mirror = mirror
+	m.flip = flip
+	m.dropImages()
+	if !mirror && !flip {
+		m.Hide()
+		return
+	}
+	m.Show()
+	m.sync()
+}
+
+func (m *mirrorLayer) invalidate() {
+	m.stale = true
+	m.faces = map[fyne.Resource]*font.Face{}
+	m.dropImages()
+	m.Refresh()
+}
+
+func (m *mirrorLayer) dropImages() {
+	for _, row := range m.rows {
+		row.image = nil
+	}
+}
+
+func (m *mirrorLayer) sync() {
+	if !m.Visible() {
+		return
+	}
+	size := m.Size()
+	if size.Width <= 0 || size.Height <= 0 {
+		return
+	}
+
+	scale := float32(1)
+	if app := fyne.CurrentApp(); app != nil {
+		if window := app.Driver().CanvasForObject(m); window != nil {
+			scale = window.Scale()
+		}
+	}
+	sourceHeight := float32(0)
+	if m.scroll.Content != nil {
+		sourceHeight = m.scroll.Content.MinSize().Height
+	}
+	if m.stale || m.source != m.scroll.Content || m.width != size.Width || m.scale != scale || m.sourceHeight != sourceHeight {
+		m.source = m.scroll.Content
+		m.sourceHeight = sourceHeight
+		m.width = size.Width
+		m.scale = scale
+		m.stale = false
+		richText, th := mirrorSource(m.scroll.Content)
+		m.rows, m.height = layoutMirrorRows(richText, th, size.Width)
+	}
+	m.place(size)
+}
+
+func (m *mirrorLayer) place(size fyne.Size) {
+	m.background.FillColor = theme.Color(theme.ColorNameBackground)
+	m.background.Move(fyne.NewPos(0, 0))
+	m.background.Resize(size)
+
+	offset := m.offset(size.Height)
+	objects := []fyne.CanvasObject{m.background}
+	for _, row := range m.rows {
+		top := row.position.Y - offset
+		if top+row.size.Height < 0 || top > size.Height {
+			row.image = nil
+			continue
+		}
+		if row.image == nil {
+			row.image = canvas.NewImageFromImage(m.drawRow(row))
+			row.image.FillMode = canvas.ImageFillStretch
+			row.image.ScaleMode = canvas.ImageScaleFastest
+		}
+		row.image.Move(mirrorPosition(row.position.X, top, row.size, size, m.mirror, m.flip))
+		row.image.Resize(row.size)
+		objects = append(objects, row.image)
+	}
+	m.objects = objects
+}
+
+func (m *mirrorLayer) offset(viewport float32) float32 {
+	if m.sourceHeight <= 0 {
+		return 0
+	}
+	ratio := m.guide.Ratio()
+	offset := readingLineFraction(m.scroll, ratio)*m.height - viewport*ratio
+	maxOffset := m.height - viewport
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+func (m *mirrorLayer) drawRow(row *mirrorRow) *image.NRGBA {
+	width := int(math.Ceil(float64(row.size.Width * m.scale)))
+	height := int(math.Ceil(float64(row.size.Height * m.scale)))
+	frame := image.NewNRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	if row.rule != nil {
+		draw.Draw(frame, frame.Bounds(), image.NewUniform(row.rule), image.Point{}, draw.Src)
+		return mirrorImage(frame, m.mirror, m.flip)
+	}
+
+	baseline := float32(0)
+	for _, run := range row.runs {
+		if face := m.face(run.style); face != nil {
+			baseline = max(baseline, faceAscent(face, run.size))
+		}
+	}
+	for _, run := range row.runs {
+		face := m.face(run.style)
+		if face == nil {
+			continue
+		}
+		renderer := render.Renderer{FontSize: run.size, PixScale: m.scale, Color: run.color}
+		renderer.DrawStringAt(run.text, frame, int(run.x*m.scale), int(math.Ceil(float64(baseline*m.scale))), face)
+	}
+	return mirrorImage(frame, m.mirror, m.flip)
+}
+
+func (m *mirrorLayer) face(style fyne.TextStyle) *font.Face {
+	resource := theme.DefaultTheme().Font(style)
+	if app := fyne.CurrentApp(); app != nil {
+		if current := app.Settings().Theme().Font(style); current != nil {
+			resource = current
+		}
+	}
+	if face, ok := m.faces[resource]; ok {
+		return face
+	}
+	face, err := font.ParseTTF(bytes.NewReader(resource.Content()))
+	if err != nil {
+		fyne.LogError("cannot load mirror font", err)
+		face = nil
+	}
+	m.faces[resource] = face
+	return face
+}
+
+func faceAscent(face *font.Face, size float32) float32 {
+	extents, ok := face.FontHExtents()
+	if !ok || face.Upem() == 0 {
+		return size
+	}
+	return extents.Ascender * size / float32(face.Upem())
+}
+
+func mirrorPosition(x, y float32, object, viewport fyne.Size, mirror, flip bool) fyne.Position {
+	if mirror {
+		x = viewport.Width - x - object.Width
+	}
+	if flip {
+		y = viewport.Height - y - object.Height
+	}
+	return fyne.NewPos(x, y)
+}
+
+func mirrorSource(object fyne.CanvasObject) (*widget.RichText, fyne.Theme) {
+	switch current := object.(type) {
+	case *widget.RichText:
+		return current, theme.Current()
+	case *container.ThemeOverride:
+		richText, _ := mirrorSource(current.Content)
+		return richText, current.Theme
+	case *fyne.Container:
+		if len(current.Objects) == 1 {
+			return mirrorSource(current.Objects[0])
+		}
+		return nil, theme.Current()
+	default:
+		return nil, theme.Current()
+	}
+}
+
+type mirrorFlow struct {
+	variant     fyne.ThemeVariant
+	left        float32
+	lineWidth   float32
+	lineSpacing float32
+	y           float32
+	gap         float32
+	rows        []*mirrorRow
+	row         *mirrorRow
+	x           float32
+	tops        []float32
+	pending     bool
+}
+
+func layoutMirrorRows(richText *widget.RichText, th fyne.Theme, width float32) ([]*mirrorRow, float32) {
+	if richText == nil {
+		return nil, 0
+	}
+	flow := flowRichText(richText, th, width)
+	return flow.rows, flow.y + th.Size(theme.SizeNameInnerPadding)
+}
+
+func segmentTops(richText *widget.RichText, th fyne.Theme, width float32) []float32 {
+	if richText == nil {
+		return nil
+	}
+	return flowRichText(richText, th, width).tops
+}
+
+func flowRichText(richText *widget.RichText, th fyne.Theme, width float32) *mirrorFlow {
+	padding := th.Size(theme.SizeNameInnerPadding)
+	flow := &mirrorFlow{
+		variant:     fyne.CurrentApp().Settings().ThemeVariant(),
+		left:        padding,
+		lineWidth:   width - padding*2,
+		lineSpacing: th.Size(theme.SizeNameLineSpacing),
+		y:           padding,
+	}
+
+	for _, segment := range richText.Segments {
+		flow.tops = append(flow.tops, flow.top())
+		flow.pending = true
+		switch current := segment.(type) {
+		case *widget.TextSegment:
+			sizeName, colorName := current.Style.SizeName, current.Style.ColorName
+			if sizeName == "" {
+				sizeName = theme.SizeNameText
+			}
+			if colorName == "" {
+				colorName = theme.ColorNameForeground
+			}
+			flow.add(current.Text, current.Style.TextStyle, th.Size(sizeName), th.Color(colorName, flow.variant), current.Style.Alignment)
+			if !current.Style.Inline {
+				flow.endParagraph()
+			}
+		case *widget.HyperlinkSegment:
+			flow.add(current.Text, fyne.TextStyle{}, th.Size(theme.SizeNameText), th.Color(theme.ColorNameHyperlink, flow.variant), current.Alignment)
+		case *widget.SeparatorSegment:
+			flow.endParagraph()
+			flow.y += flow.gap
+			thickness := th.Size(theme.SizeNameSeparatorThickness)
+			flow.rows = append(flow.rows, &mirrorRow{
+				rule:     th.Color(theme.ColorNameSeparator, flow.variant),
+				position: fyne.NewPos(flow.left, flow.y),
+				size:     fyne.NewSize(flow.lineWidth, thickness),
+			})
+			flow.y += thickness
+			flow.gap = flow.lineSpacing
+		}
+	}
+	flow.breakRow()
+	flow.tops = append(flow.tops, flow.y)
+	return flow
+}
+
+func (f *mirrorFlow) top() float32 {
+	if f.row == nil {
+		return f.y + f.gap
+	}
+	return f.y
+}
+
+func (f *mirrorFlow) add(text string, style fyne.TextStyle, size float32, textColor color.Color, align fyne.TextAlign) {
+	text = strings.ReplaceAll(text, "\t", "    ")
+	for index, line := range strings.Split(text, "\n") {
+		if index > 0 {
+			f.breakRow()
+		}
+		f.startRow(size, style, align)
+		for _, word := range strings.SplitAfter(line, " ") {
+			if word == "" {
+				continue
+			}
+			trimmed := strings.TrimRight(word, " ")
+			if trimmed != "" && f.x > 0 && f.x+fyne.MeasureText(trimmed, size, style).Width > f.lineWidth {
+				f.breakRow()
+				f.startRow(size, style, align)
+			}
+			if trimmed == "" && f.x == 0 {
+				continue
+			}
+			f.append(word, style, size, textColor)
+		}
+	}
+}
+
+func (f *mirrorFlow) startRow(size float32, style fyne.TextStyle, align fyne.TextAlign) {
+	if f.row == nil {
+		f.y += f.gap
+		f.gap = 0
+		f.row = &mirrorRow{align: align}
+		f.x = 0
+	}
+	f.row.size.Height = max(f.row.size.Height, fyne.MeasureText("M", size, style).Height)
+}
+
+func (f *mirrorFlow) append(word string, style fyne.TextStyle, size float32, textColor color.Color) {
+	if f.pending {
+		f.tops[len(f.tops)-1] = f.y
+		f.pending = false
+	}
+	if last := len(f.row.runs) - 1; last >= 0 {
+		run := &f.row.runs[last]
+		if run.style == style && run.size == size && run.color == textColor {
+			run.text += word
+			f.x = run.x + fyne.MeasureText(run.text, size, style).Width
+			f.row.size.Width = f.x
+			return
+		}
+	}
+	f.row.runs = append(f.row.runs, mirrorRun{text: word, style: style, size: size, color: textColor, x: f.x})
+	f.x += fyne.MeasureText(word, size, style).Width
+	f.row.size.Width = f.x
+}
+
+func (f *mirrorFlow) breakRow() {
+	if f.row == nil {
+		return
+	}
+	row := f.row
+	x := f.left
+	switch row.align {
+	case fyne.TextAlignCenter:
+		x += (f.lineWidth - row.size.Width) / 2
+	case fyne.TextAlignTrailing:
+		x += f.lineWidth - row.size.Width
+	}
+	row.position = fyne.NewPos(x, f.y)
+	f.y += row.size.Height
+	if len(row.runs) > 0 {
+		f.rows = append(f.rows, row)
+	}
+	f.row = nil
+}
+
+func (f *mirrorFlow) endParagraph() {
+	if f.row == nil {
+		return
+	}
+	f.breakRow()
+	f.gap = f.lineSpacing
+}
+
+func mirrorImage(frame *image.NRGBA, horizontal, vertical bool) *image.NRGBA {
+	width, height := frame.Rect.Dx(), frame.Rect.Dy()
+	rowBytes := width * 4
+	if horizontal {
+		for y := 0; y < height; y++ {
+			row := frame.Pix[y*frame.Stride : y*frame.Stride+rowBytes]
+			for left, right := 0, (width-1)*4; left < right; left, right = left+4, right-4 {
+				for i := 0; i < 4; i++ {
+					row[left+i], row[right+i] = row[right+i], row[left+i]
+				}
+			}
+		}
+	}
+	if vertical {
+		swap := make([]byte, rowBytes)
+		for top, bottom := 0, height-1; top < bottom; top, bottom = top+1, bottom-1 {
+			topRow := frame.Pix[top*frame.Stride : top*frame.Stride+rowBytes]
+			bottomRow := frame.Pix[bottom*frame.Stride : bottom*frame.Stride+rowBytes]
+			copy(swap, topRow)
+			copy(topRow, bottomRow)
+			copy(bottomRow, swap)
+		}
+	}
+	return frame
+}
+
+type mirrorRenderer struct {
+	layer *mirrorLayer
+}
+
+func (r *mirrorRenderer) Destroy() {}
+
+func (r *mirrorRenderer) Layout(fyne.Size) {
+	r.layer.sync()
+}
+
+func (r *mirrorRenderer) MinSize() fyne.Size {
+	return fyne.Size{}
+}
+
+func (r *mirrorRenderer) Objects() []fyne.CanvasObject {
+	return r.layer.objects
+}
+
+func (r *mirrorRenderer) Refresh() {
+	r.layer.sync()
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"grompt/internal/content"
+)
+
+func TestMirrorImage(t *testing.T) {
+	frame := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	frame.SetNRGBA(0, 0, color.NRGBA{R: 255, A: 255})
+
+	tests := []struct {
+		name       string
+		horizontal bool
+		vertical   bool
+		x, y       int
+	}{
+		{name: "none", x: 0, y: 0},
+		{name: "mirror", horizontal: true, x: 1, y: 0},
+		{name: "flip", vertical: true, x: 0, y: 1},
+		{name: "both", horizontal: true, vertical: true, x: 1, y: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copied := image.NewNRGBA(frame.Rect)
+			copy(copied.Pix, frame.Pix)
+			mirrored := mirrorImage(copied, tt.horizontal, tt.vertical)
+			if got := mirrored.NRGBAAt(tt.x, tt.y); got.R != 255 {
+				t.Fatalf("pixel (%d,%d) = %v, want the red corner", tt.x, tt.y, got)
+			}
+		})
+	}
+}
+
+func TestMirrorPosition(t *testing.T) {
+	viewport := fyne.NewSize(100, 200)
+	object := fyne.NewSize(30, 20)
+
+	tests := []struct {
+		name   string
+		mirror bool
+		flip   bool
+		want   fyne.Position
+	}{
+		{name: "none", want: fyne.NewPos(10, 40)},
+		{name: "mirror", mirror: true, want: fyne.NewPos(60, 40)},
+		{name: "flip", flip: true, want: fyne.NewPos(10, 140)},
+		{name: "both", mirror: true, flip: true, want: fyne.NewPos(60, 140)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mirrorPosition(10, 40, object, viewport, tt.mirror, tt.flip); got != tt.want {
+				t.Fatalf("mirrorPosition = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutMirrorRowsFollowsRichText(t *testing.T) {
+	test.NewTempApp(t)
+
+	richText := widget.NewRichText(
+		&widget.TextSegment{Style: widget.RichTextStyleHeading, Text: "Opening"},
+		&widget.TextSegment{Style: widget.RichTextStyleInline, Text: strings.Repeat("Good evening and welcome to the show. ", 6)},
+		&widget.TextSegment{Style: widget.RichTextStyleStrong, Text: "Stay tuned."},
+		&widget.SeparatorSegment{},
+		&widget.TextSegment{Style: widget.RichTextStyleParagraph, Text: "Closing line."},
+	)
+	richText.Wrapping = fyne.TextWrapWord
+	width := float32(320)
+	richText.Resize(fyne.NewSize(width, richText.MinSize().Height))
+
+	rows, height := layoutMirrorRows(richText, theme.Current(), width)
+	if len(rows) < 5 {
+		t.Fatalf("got %d rows, want the paragraph to wrap", len(rows))
+	}
+	for index, row := range rows {
+		if row.position.X+row.size.Width > width+1 {
+			t.Fatalf("row %d overflows: %v + %v", index, row.position, row.size)
+		}
+		if index > 0 && row.position.Y < rows[index-1].position.Y+rows[index-1].size.Height {
+			t.Fatalf("row %d overlaps the previous row", index)
+		}
+	}
+	if rows[len(rows)-2].rule == nil {
+		t.Fatal("separator did not produce a rule row")
+	}
+
+	want := richText.MinSize().Height
+	if math.Abs(float64(height-want)) > float64(theme.Size(theme.SizeNameText)) {
+		t.Fatalf("mirrored height = %v, want about %v", height, want)
+	}
+}
+
+func TestSegmentTopsPlaceCuesAndSections(t *testing.T) {
+	app := test.NewTempApp(t)
+	app.Settings().SetTheme(NewTypographyTheme(MinContentFontSize))
+
+	source := "# Opening\n\n![A wide chart of the quarterly results](chart.png)\n\n" +
+		strings.Repeat("Good evening and welcome to the show. ", 6) + "[[pause]] Closing words.\n\n## Next\n\nMore."
+	doc, err := content.Parse([]byte(source), content.FormatMarkdown)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	object, cues := content.RenderDocument(doc, content.DefaultRenderOptions())
+	richText := object.(*widget.RichText)
+	width := float32(320)
+	richText.Resize(fyne.NewSize(width, richText.MinSize().Height))
+	tops := segmentTops(richText, theme.Current(), width)
+	if len(tops) != len(richText.Segments)+1 {
+		t.Fatalf("got %d tops for %d segments", len(tops), len(richText.Segments))
+	}
+
+	rows := map[string]float32{}
+	for _, object := range test.WidgetRenderer(richText).Objects() {
+		if text, ok := object.(*canvas.Text); ok {
+			rows[strings.TrimSpace(text.Text)] = text.Position().Y
+		}
+	}
+	sections := content.Outline(doc)
+	if len(cues) != 1 || len(sections) != 2 {
+		t.Fatalf("got %d cues and %d sections", len(cues), len(sections))
+	}
+
+	tests := []struct {
+		name    string
+		segment int
+		text    string
+	}{
+		{name: "cue after an image", segment: cues[0].Segment, text: "Closing words."},
+		{name: "first section", segment: sections[0].Segment, text: "Opening"},
+		{name: "second section", segment: sections[1].Segment, text: "Next"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, ok := rows[tt.text]
+			if !ok {
+				t.Fatalf("no row shows %q", tt.text)
+			}
+			if got := tops[tt.segment]; math.Abs(float64(got-want)) > 1 {
+				t.Fatalf("anchor at %v, want the row of %q at %v", got, tt.text, want)
+			}
+		})
+	}
+}
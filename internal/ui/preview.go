@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+type runoutLayout struct {
+	scroll *container.Scroll
+	ratio  func() float32
+}
+
+func newRunout(scroll *container.Scroll, ratio func() float32, document fyne.CanvasObject) *fyne.Container {
+	return container.New(&runoutLayout{scroll: scroll, ratio: ratio}, document)
+}
+
+func (l *runoutLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	size := objects[0].MinSize()
+	size.Height += l.scroll.Size().Height * (1 - l.ratio())
+	return size
+}
+
+func (l *runoutLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	objects[0].Move(fyne.NewPos(0, 0))
+	objects[0].Resize(fyne.NewSize(size.Width, objects[0].MinSize().Height))
+}
+
+func documentHeight(scroll *container.Scroll) float32 {
+	if scroll.Content == nil {
+		return 0
+	}
+	if runout, ok := scroll.Content.(*fyne.Container); ok {
+		if _, ok := runout.Layout.(*runoutLayout); ok {
+			return runout.Objects[0].MinSize().Height
+		}
+	}
+	return scroll.Content.MinSize().Height
+}
+
+func readingLineFraction(scroll *container.Scroll, ratio float32) float32 {
+	height := documentHeight(scroll)
+	if height <= 0 {
+		return 0
+	}
+	return (scroll.Offset.Y + scroll.Size().Height*ratio) / height
+}
+
+func followScroll(leader, follower *container.Scroll, ratio float32) {
+	if follower.Content == nil {
+		return
+	}
+
+	contentHeight := documentHeight(follower)
+	maxOffset := follower.Content.MinSize().Height - follower.Size().Height
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+
+	offset := readingLineFraction(leader, ratio)*contentHeight - follower.Size().Height*ratio
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if math.Abs(float64(offset-follower.Offset.Y)) < 0.5 {
+		return
+	}
+	follower.ScrollToOffset(fyne.NewPos(0, offset))
+}
+
+func adjacentSection(positions []float32, position, tolerance float32, forward bool) int {
+	if forward {
+		for i, section := range positions {
+			if section > position+tolerance {
+				return i
+			}
+		}
+		return -1
+	}
+	for i := len(positions) - 1; i >= 0; i-- {
+		if positions[i] < position-tolerance {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestRunoutReachesTheReadingLine(t *testing.T) {
+	test.NewTempApp(t)
+
+	tests := []struct {
+		name  string
+		ratio float32
+	}{
+		{name: "top", ratio: 0.2},
+		{name: "third", ratio: 1.0 / 3},
+		{name: "center", ratio: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := canvas.NewRectangle(nil)
+			document.SetMinSize(fyne.NewSize(100, 1000))
+			scroll := container.NewVScroll(nil)
+			scroll.Content = newRunout(scroll, func() float32 { return tt.ratio }, document)
+			scroll.Resize(fyne.NewSize(100, 240))
+			scroll.ScrollToOffset(fyne.NewPos(0, scroll.Content.MinSize().Height))
+
+			if got := documentHeight(scroll); got != 1000 {
+				t.Fatalf("documentHeight = %v, want 1000", got)
+			}
+			if got := readingLineFraction(scroll, tt.ratio); got < 0.999 || got > 1.001 {
+				t.Fatalf("reading line at the end of the scroll = %v of the document, want 1", got)
+			}
+		})
+	}
+}
@@ -7,30 +7,136 @@ import (
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
 )
 
 const (
-	clearReadingLines = float32(3)
 	chevronLineHeight = float32(3)
 	minSideGutter     = float32(40)
+	highlightBarAlpha = 48
 )
 
 type scrollFadeLayout struct {
-	clearLines         float32
+	guide              *ReadingGuide
+	flip               *bool
 	lineHeightProvider func() float32
+	marginProvider     func() int
 }
 
-func NewScrollWithFade(scroll *container.Scroll, lineHeightProvider func() float32) fyne.CanvasObject {
-	background := color.NRGBAModel.Convert(theme.Color(theme.ColorNameBackground)).(color.NRGBA)
-	topGradient := canvas.NewVerticalGradient(withAlpha(background, 230), withAlpha(background, 0))
-	bottomGradient := canvas.NewVerticalGradient(withAlpha(background, 0), withAlpha(background, 230))
-	leftChevron := canvas.NewText(">", withThemeAlpha(theme.Color(theme.ColorNameForeground), 220))
-	rightChevron := canvas.NewText("<", withThemeAlpha(theme.Color(theme.ColorNameForeground), 220))
+type ScrollWithFade struct {
+	widget.BaseWidget
+
+	fade      *fyne.Container
+	guide     ReadingGuide
+	gradients []*canvas.LinearGradient
+	chevrons  []*canvas.Text
+	highlight *canvas.Rectangle
+	mirrored  *mirrorLayer
+	mirror    bool
+	flip      bool
+}
 
-	return container.New(&scrollFadeLayout{
-		clearLines:         clearReadingLines,
+func NewScrollWithFade(scroll *container.Scroll, guide ReadingGuide, lineHeightProvider func() float32, marginProvider func() int) *ScrollWithFade {
+	topGradient := canvas.NewVerticalGradient(color.Transparent, color.Transparent)
+	bottomGradient := canvas.NewVerticalGradient(color.Transparent, color.Transparent)
+	leftChevron := canvas.NewText("", color.Transparent)
+	rightChevron := canvas.NewText("", color.Transparent)
+	highlight := canvas.NewRectangle(color.Transparent)
+
+	s := &ScrollWithFade{
+		guide:     guide,
+		gradients: []*canvas.LinearGradient{topGradient, bottomGradient},
+		chevrons:  []*canvas.Text{leftChevron, rightChevron},
+		highlight: highlight,
+	}
+	s.mirrored = newMirrorLayer(scroll, &s.guide)
+	s.fade = container.New(&scrollFadeLayout{
+		guide:              &s.guide,
+		flip:               &s.flip,
 		lineHeightProvider: lineHeightProvider,
-	}, scroll, topGradient, bottomGradient, leftChevron, rightChevron)
+		marginProvider:     marginProvider,
+	}, scroll, s.mirrored, topGradient, bottomGradient, leftChevron, rightChevron, highlight)
+	s.applyGuide()
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *ScrollWithFade) Guide() ReadingGuide {
+	return s.guide
+}
+
+func (s *ScrollWithFade) SetGuide(guide ReadingGuide) {
+	s.guide = guide
+	s.applyGuide()
+	s.Refresh()
+}
+
+func (s *ScrollWithFade) applyGuide() {
+	left, right := "", ""
+	switch s.guide.Marker {
+	case GuideMarkerChevrons:
+		left, right = ">", "<"
+	case GuideMarkerArrows:
+		left, right = "→", "←"
+	}
+	s.chevrons[0].Text = left
+	s.chevrons[1].Text = right
+	for _, chevron := range s.chevrons {
+		if chevron.Text == "" {
+			chevron.Hide()
+		} else {
+			chevron.Show()
+		}
+	}
+	if s.guide.Marker == GuideMarkerBar {
+		s.highlight.Show()
+	} else {
+		s.highlight.Hide()
+	}
+	s.applyThemeColors()
+}
+
+func (s *ScrollWithFade) applyThemeColors() {
+	background := color.NRGBAModel.Convert(theme.Color(theme.ColorNameBackground)).(color.NRGBA)
+	alpha := s.guide.fadeAlpha()
+	s.gradients[0].StartColor = withAlpha(background, alpha)
+	s.gradients[0].EndColor = withAlpha(background, 0)
+	s.gradients[1].StartColor = withAlpha(background, 0)
+	s.gradients[1].EndColor = withAlpha(background, alpha)
+	for _, chevron := range s.chevrons {
+		chevron.Color = withThemeAlpha(theme.Color(ColorNameReadingMarker), 220)
+	}
+	s.highlight.FillColor = withThemeAlpha(theme.Color(ColorNameReadingMarker), highlightBarAlpha)
+}
+
+func (s *ScrollWithFade) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(s.fade)
+}
+
+func (s *ScrollWithFade) Mirror() (bool, bool) {
+	return s.mirror, s.flip
+}
+
+func (s *ScrollWithFade) SetMirror(mirror, flip bool) {
+	s.mirror = mirror
+	s.flip = flip
+	s.mirrored.SetMirror(mirror, flip)
+	s.fade.Refresh()
+}
+
+func (s *ScrollWithFade) Refresh() {
+	s.applyThemeColors()
+	s.fade.Refresh()
+	s.mirrored.invalidate()
+	s.BaseWidget.Refresh()
+}
+
+func (s *ScrollWithFade) RefreshMirror() {
+	if !s.mirrored.Visible() {
+		return
+	}
+	s.mirrored.sync()
+	canvas.Refresh(s.mirrored)
 }
 
 func (l *scrollFadeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
@@ -47,13 +153,23 @@ func (l *scrollFadeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 		}
 	}
 
-	clearBandHeight := lineHeight * l.clearLines
+	clearBandHeight := lineHeight * float32(NormalizeGuideLines(l.guide.Lines))
 	if clearBandHeight > size.Height {
 		clearBandHeight = size.Height
 	}
-	fadeHeight := (size.Height - clearBandHeight) / 2
-	if fadeHeight < 0 {
-		fadeHeight = 0
+	ratio := l.guide.Ratio()
+	if l.flip != nil && *l.flip {
+		ratio = 1 - ratio
+	}
+	centerY := size.Height * ratio
+	bandTop := centerY - clearBandHeight/2
+	if bandTop < 0 {
+		bandTop = 0
+	}
+	bandBottom := bandTop + clearBandHeight
+	if bandBottom > size.Height {
+		bandBottom = size.Height
+		bandTop = bandBottom - clearBandHeight
 	}
 
 	chevronSize := lineHeight * chevronLineHeight
@@ -62,6 +178,11 @@ func (l *scrollFadeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 	}
 
 	sideGutter := chevronSize*0.95 + minSideGutter
+	if l.marginProvider != nil {
+		if margin := size.Width * float32(l.marginProvider()) / 100; margin > sideGutter {
+			sideGutter = margin
+		}
+	}
 	maxGutter := (size.Width - 120) / 2
 	if maxGutter < minSideGutter {
 		maxGutter = minSideGutter
@@ -87,26 +208,30 @@ func (l *scrollFadeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 	scroll.Move(fyne.NewPos(scrollX, 0))
 	scroll.Resize(fyne.NewSize(scrollWidth, size.Height))
 
-	if len(objects) < 3 {
+	if len(objects) < 4 {
 		return
 	}
 
-	topGradient := objects[1]
-	bottomGradient := objects[2]
+	mirrored := objects[1]
+	mirrored.Move(fyne.NewPos(scrollX, 0))
+	mirrored.Resize(fyne.NewSize(scrollWidth, size.Height))
+
+	topGradient := objects[2]
+	bottomGradient := objects[3]
 
 	topGradient.Move(fyne.NewPos(scrollX, 0))
-	topGradient.Resize(fyne.NewSize(scrollWidth, fadeHeight))
+	topGradient.Resize(fyne.NewSize(scrollWidth, bandTop))
 
-	bottomGradient.Move(fyne.NewPos(scrollX, size.Height-fadeHeight))
-	bottomGradient.Resize(fyne.NewSize(scrollWidth, fadeHeight))
+	bottomGradient.Move(fyne.NewPos(scrollX, bandBottom))
+	bottomGradient.Resize(fyne.NewSize(scrollWidth, size.Height-bandBottom))
 
-	if len(objects) < 5 {
+	if len(objects) < 6 {
 		return
 	}
 
-	centerY := fadeHeight + (clearBandHeight / 2)
+	centerY = bandTop + clearBandHeight/2
 
-	leftChevron, ok := objects[3].(*canvas.Text)
+	leftChevron, ok := objects[4].(*canvas.Text)
 	if ok {
 		leftChevron.TextSize = chevronSize
 		leftChevron.Refresh()
@@ -114,13 +239,21 @@ func (l *scrollFadeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 		leftChevron.Move(fyne.NewPos(scrollX-leftSize.Width-20, centerY-(leftSize.Height/2)))
 	}
 
-	rightChevron, ok := objects[4].(*canvas.Text)
+	rightChevron, ok := objects[5].(*canvas.Text)
 	if ok {
 		rightChevron.TextSize = chevronSize
 		rightChevron.Refresh()
 		rightSize := rightChevron.MinSize()
 		rightChevron.Move(fyne.NewPos(scrollX+scrollWidth+20, centerY-(rightSize.Height/2)))
 	}
+
+	if len(objects) < 7 {
+		return
+	}
+
+	highlight := objects[6]
+	highlight.Move(fyne.NewPos(scrollX, bandTop))
+	highlight.Resize(fyne.NewSize(scrollWidth, clearBandHeight))
 }
 
 func (l *scrollFadeLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	appconfig "grompt/internal/config"
+	"grompt/internal/content"
+	"grompt/internal/input"
+	scrollengine "grompt/internal/scroll"
+)
+
+type resolvedSettings struct {
+	speed         float64
+	fontSize      float32
+	font          fontFamily
+	wordSpacing   int
+	lineHeight    float32
+	letterSpacing float32
+	margin        int
+	easing        scrollengine.Easing
+	ramp          time.Duration
+	unit          scrollengine.SpeedUnit
+	wpm           float64
+	mirror        bool
+	flip          bool
+	colorScheme   ColorScheme
+	guide         ReadingGuide
+	foreground    color.Color
+	background    color.Color
+	keymap        input.Keymap
+	remoteAddr    string
+	remoteToken   string
+	oscAddr       string
+	oscFeedback   string
+	scriptsDir    string
+}
+
+func resolveSettings(loaded appconfig.FileSettings) (resolvedSettings, []string) {
+	var warnings []string
+	resolved := resolvedSettings{
+		speed:         scrollengine.DefaultSpeed,
+		fontSize:      DefaultContentFontSize,
+		font:          defaultFontFamily(),
+		wordSpacing:   content.DefaultRenderOptions().WordSpacing,
+		lineHeight:    content.DefaultLineHeight,
+		letterSpacing: DefaultLetterSpacing,
+		margin:        DefaultContentMargin,
+		easing:        scrollengine.DefaultEasing,
+		ramp:          scrollengine.DefaultRampDuration,
+		unit:          scrollengine.UnitPixels,
+		wpm:           scrollengine.DefaultWPM,
+		colorScheme:   ColorSchemeSystem,
+		guide:         DefaultReadingGuide(),
+	}
+
+	if loaded.Speed != nil {
+		next := *loaded.Speed
+		if next < scrollengine.DefaultMinSpeed || next > scrollengine.DefaultMaxSpeed {
+			warnings = append(warnings, fmt.Sprintf("speed %.0f out of range, clamped", next))
+		}
+		if next < scrollengine.DefaultMinSpeed {
+			next = scrollengine.DefaultMinSpeed
+		}
+		if next > scrollengine.DefaultMaxSpeed {
+			next = scrollengine.DefaultMaxSpeed
+		}
+		resolved.speed = next
+	}
+
+	if loaded.FontSize != nil {
+		next := *loaded.FontSize
+		normalized := clampFontSize(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("font_size %.0f out of range, clamped", next))
+		}
+		resolved.fontSize = normalized
+	}
+
+	if loaded.Font != nil {
+		if family, err := loadFontFamily(*loaded.Font); err == nil {
+			resolved.font = family
+		} else {
+			resolved.font = fontFamily{name: *loaded.Font}
+			warnings = append(warnings, fmt.Sprintf("cannot load font %q: %v", *loaded.Font, err))
+		}
+	}
+
+	if loaded.WordSpacing != nil {
+		next := *loaded.WordSpacing
+		normalized := content.NormalizeWordSpacing(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("word_spacing %d out of range, clamped", next))
+		}
+		resolved.wordSpacing = normalized
+	}
+
+	if loaded.LineHeight != nil {
+		next := *loaded.LineHeight
+		normalized := content.NormalizeLineHeight(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("line_height %.2f out of range, clamped", next))
+		}
+		resolved.lineHeight = normalized
+	}
+
+	if loaded.LetterSpacing != nil {
+		next := *loaded.LetterSpacing
+		normalized := clampLetterSpacing(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("letter_spacing %.2f out of range, clamped", next))
+		}
+		resolved.letterSpacing = normalized
+	}
+
+	if loaded.Margin != nil {
+		next := *loaded.Margin
+		normalized := clampMargin(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("margin %d out of range, clamped", next))
+		}
+		resolved.margin = normalized
+	}
+
+	if loaded.Easing != nil {
+		if parsed, ok := scrollengine.ParseEasing(*loaded.Easing); ok {
+			resolved.easing = parsed
+		} else {
+			warnings = append(warnings, fmt.Sprintf("unknown easing %q ignored", *loaded.Easing))
+		}
+	}
+
+	if loaded.RampMillis != nil {
+		next := time.Duration(*loaded.RampMillis) * time.Millisecond
+		normalized := scrollengine.ClampRampDuration(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("ramp_ms %d out of range, clamped", *loaded.RampMillis))
+		}
+		resolved.ramp = normalized
+	}
+
+	if loaded.SpeedUnit != nil {
+		if parsed, ok := scrollengine.ParseSpeedUnit(*loaded.SpeedUnit); ok {
+			resolved.unit = parsed
+		} else {
+			warnings = append(warnings, fmt.Sprintf("unknown speed_unit %q ignored", *loaded.SpeedUnit))
+		}
+	}
+
+	if loaded.WPM != nil {
+		next := *loaded.WPM
+		normalized := scrollengine.ClampWPM(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("wpm %.0f out of range, clamped", next))
+		}
+		resolved.wpm = normalized
+	}
+
+	resolved.mirror = loaded.Mirror != nil && *loaded.Mirror
+	resolved.flip = loaded.Flip != nil && *loaded.Flip
+
+	if loaded.ColorScheme != nil {
+		if parsed, ok := ParseColorScheme(*loaded.ColorScheme); ok {
+			resolved.colorScheme = parsed
+		} else {
+			warnings = append(warnings, fmt.Sprintf("unknown color_scheme %q ignored", *loaded.ColorScheme))
+		}
+	}
+	if loaded.Foreground != nil {
+		if parsed, ok := parseHexColor(*loaded.Foreground); ok {
+			resolved.foreground = parsed
+		} else {
+			warnings = append(warnings, fmt.Sprintf("invalid foreground %q ignored", *loaded.Foreground))
+		}
+	}
+	if loaded.Background != nil {
+		if parsed, ok := parseHexColor(*loaded.Background); ok {
+			resolved.background = parsed
+		} else {
+			warnings = append(warnings, fmt.Sprintf("invalid background %q ignored", *loaded.Background))
+		}
+	}
+
+	if resolved.colorScheme == ColorSchemeCustom && resolved.foreground == nil && resolved.background == nil {
+		warnings = append(warnings, "color_scheme custom needs foreground or background colours")
+	}
+	if resolved.colorScheme != ColorSchemeCustom && (resolved.foreground != nil || resolved.background != nil) {
+		warnings = append(warnings, "foreground and background only apply with color_scheme custom")
+	}
+
+	if loaded.GuidePosition != nil {
+		if parsed, ok := ParseGuidePosition(*loaded.GuidePosition); ok {
+			resolved.guide.Position = parsed
+		} else {
+			warnings = append(warnings, fmt.Sprintf("unknown guide_position %q ignored", *loaded.GuidePosition))
+		}
+	}
+	if loaded.GuideLines != nil {
+		next := *loaded.GuideLines
+		normalized := NormalizeGuideLines(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("guide_lines %d out of range, clamped", next))
+		}
+		resolved.guide.Lines = normalized
+	}
+	if loaded.GuideFade != nil {
+		next := *loaded.GuideFade
+		normalized := NormalizeGuideFade(next)
+		if normalized != next {
+			warnings = append(warnings, fmt.Sprintf("guide_fade %d out of range, clamped", next))
+		}
+		resolved.guide.Fade = normalized
+	}
+	if loaded.GuideMarker != nil {
+		if parsed, ok := ParseGuideMarker(*loaded.GuideMarker); ok {
+			resolved.guide.Marker = parsed
+		} else {
+			warnings = append(warnings, fmt.Sprintf("unknown guide_marker %q ignored", *loaded.GuideMarker))
+		}
+	}
+
+	keymap, keymapWarnings := input.BuildKeymap(loaded.Keymap)
+	resolved.keymap = keymap
+	warnings = append(warnings, keymapWarnings...)
+
+	if loaded.RemoteAddr != nil {
+		resolved.remoteAddr = *loaded.RemoteAddr
+	}
+	if loaded.RemoteToken != nil {
+		resolved.remoteToken = *loaded.RemoteToken
+	}
+	if loaded.OSCAddr != nil {
+		resolved.oscAddr = *loaded.OSCAddr
+	}
+	if loaded.OSCFeedback != nil {
+		resolved.oscFeedback = *loaded.OSCFeedback
+	}
+	if loaded.ScriptsDir != nil {
+		resolved.scriptsDir = *loaded.ScriptsDir
+	}
+	return resolved, warnings
+}
+
+func (r resolvedSettings) settings(profile string) appconfig.Settings {
+	return appconfig.Settings{
+		Profile:       profile,
+		Speed:         r.speed,
+		FontSize:      r.fontSize,
+		Font:          r.font.name,
+		WordSpacing:   r.wordSpacing,
+		LineHeight:    r.lineHeight,
+		LetterSpacing: r.letterSpacing,
+		Margin:        r.margin,
+		Easing:        string(r.easing),
+		RampMillis:    int(r.ramp / time.Millisecond),
+		SpeedUnit:     string(r.unit),
+		WPM:           r.wpm,
+		Mirror:        r.mirror,
+		Flip:          r.flip,
+		ColorScheme:   string(r.colorScheme),
+		GuidePosition: string(r.guide.Position),
+		GuideLines:    r.guide.Lines,
+		GuideFade:     r.guide.Fade,
+		GuideMarker:   string(r.guide.Marker),
+	}
+}
+
+func parseHexColor(value string) (color.Color, bool) {
+	hex := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, false
+	}
+	parsed, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	return color.NRGBA{
+		R: uint8(parsed >> 24),
+		G: uint8(parsed >> 16),
+		B: uint8(parsed >> 8),
+		A: uint8(parsed),
+	}, true
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"image/color"
+	"math"
 	"sync"
 
 	"fyne.io/fyne/v2"
@@ -16,26 +17,128 @@ const (
 	ContentFontStep        float32 = 2
 )
 
+const (
+	DefaultLetterSpacing float32 = 0
+	MinLetterSpacing     float32 = 0
+	MaxLetterSpacing     float32 = 0.3
+	LetterSpacingStep    float32 = 0.02
+)
+
+const (
+	DefaultContentMargin = 0
+	MinContentMargin     = 0
+	MaxContentMargin     = 30
+	ContentMarginStep    = 2
+)
+
 type TypographyTheme struct {
-	mu       sync.RWMutex
-	base     fyne.Theme
-	bodySize float32
+	mu         sync.RWMutex
+	base       fyne.Theme
+	bodySize   float32
+	lineHeight float32
+	tracking   float32
+	margin     int
+	fonts      fontFamily
+	scheme     ColorScheme
+	foreground color.Color
+	background color.Color
 }
 
 func NewTypographyTheme(bodySize float32) *TypographyTheme {
 	size := clampFontSize(bodySize)
 	return &TypographyTheme{
-		base:     theme.DefaultTheme(),
-		bodySize: size,
+		base:       theme.DefaultTheme(),
+		bodySize:   size,
+		lineHeight: content.DefaultLineHeight,
+		fonts:      defaultFontFamily(),
+		scheme:     ColorSchemeSystem,
 	}
 }
 
 func (t *TypographyTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
-	return t.base.Color(name, variant)
+	t.mu.RLock()
+	scheme, foreground, background := t.scheme, t.foreground, t.background
+	t.mu.RUnlock()
+	if scheme != ColorSchemeCustom {
+		foreground, background = nil, nil
+	}
+
+	var marker color.Color
+	if colors, ok := palettes[scheme]; ok {
+		variant = colors.variant
+		marker = colors.marker
+		if foreground == nil {
+			foreground = colors.foreground
+		}
+		if background == nil {
+			background = colors.background
+		}
+	}
+
+	switch {
+	case name == theme.ColorNameForeground && foreground != nil:
+		return foreground
+	case name == theme.ColorNameBackground && background != nil:
+		return background
+	case name == ColorNameReadingMarker && marker != nil:
+		return marker
+	case name == ColorNameReadingMarker && foreground != nil:
+		return foreground
+	case name == ColorNameReadingMarker:
+		return t.base.Color(theme.ColorNameForeground, variant)
+	default:
+		return t.base.Color(name, variant)
+	}
+}
+
+func (t *TypographyTheme) ColorScheme() ColorScheme {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.scheme
+}
+
+func (t *TypographyTheme) SetColorScheme(scheme ColorScheme) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scheme = scheme
+}
+
+func (t *TypographyTheme) SetColors(scheme ColorScheme, foreground, background color.Color) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scheme = scheme
+	t.foreground = foreground
+	t.background = background
 }
 
 func (t *TypographyTheme) Font(style fyne.TextStyle) fyne.Resource {
-	return t.base.Font(style)
+	t.mu.RLock()
+	tracking, lineHeight, fonts := t.tracking, t.lineHeight, t.fonts
+	t.mu.RUnlock()
+
+	if style.Symbol {
+		return t.base.Font(style)
+	}
+	font := fonts.font(style)
+	if font == nil {
+		font = t.base.Font(style)
+	}
+	if !content.IsContentStyle(style) {
+		lineHeight = content.DefaultLineHeight
+	}
+	return adjustedFont(font, tracking, lineHeight)
+}
+
+func (t *TypographyTheme) FontName() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.fonts.name
+}
+
+func (t *TypographyTheme) setFontFamily(family fontFamily) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fonts = family
 }
 
 func (t *TypographyTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
@@ -65,6 +168,13 @@ func (t *TypographyTheme) BodySize() float32 {
 	return t.bodySize
 }
 
+func (t *TypographyTheme) SetBodySize(size float32) float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bodySize = clampFontSize(size)
+	return t.bodySize
+}
+
 func (t *TypographyTheme) IncreaseBodySize() float32 {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -79,8 +189,89 @@ func (t *TypographyTheme) DecreaseBodySize() float32 {
 	return t.bodySize
 }
 
+func (t *TypographyTheme) LineHeight() float32 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lineHeight
+}
+
+func (t *TypographyTheme) SetLineHeight(lineHeight float32) float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lineHeight = content.NormalizeLineHeight(lineHeight)
+	return t.lineHeight
+}
+
+func (t *TypographyTheme) IncreaseLineHeight() float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lineHeight = content.NormalizeLineHeight(roundStep(t.lineHeight + content.LineHeightStep))
+	return t.lineHeight
+}
+
+func (t *TypographyTheme) DecreaseLineHeight() float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lineHeight = content.NormalizeLineHeight(roundStep(t.lineHeight - content.LineHeightStep))
+	return t.lineHeight
+}
+
+func (t *TypographyTheme) LetterSpacing() float32 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tracking
+}
+
+func (t *TypographyTheme) SetLetterSpacing(spacing float32) float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tracking = clampLetterSpacing(spacing)
+	return t.tracking
+}
+
+func (t *TypographyTheme) IncreaseLetterSpacing() float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tracking = clampLetterSpacing(roundStep(t.tracking + LetterSpacingStep))
+	return t.tracking
+}
+
+func (t *TypographyTheme) DecreaseLetterSpacing() float32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tracking = clampLetterSpacing(roundStep(t.tracking - LetterSpacingStep))
+	return t.tracking
+}
+
+func (t *TypographyTheme) Margin() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.margin
+}
+
+func (t *TypographyTheme) SetMargin(margin int) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.margin = clampMargin(margin)
+	return t.margin
+}
+
+func (t *TypographyTheme) IncreaseMargin() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.margin = clampMargin(t.margin + ContentMarginStep)
+	return t.margin
+}
+
+func (t *TypographyTheme) DecreaseMargin() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.margin = clampMargin(t.margin - ContentMarginStep)
+	return t.margin
+}
+
 func estimatedLineHeight(fontSize float32) float32 {
-	return fontSize + theme.DefaultTheme().Size(theme.SizeNameLineSpacing)
+	return fyne.MeasureText("Mg", fontSize, fyne.TextStyle{TabWidth: content.ContentTabWidth}).Height
 }
 
 func clampFontSize(size float32) float32 {
@@ -92,3 +283,27 @@ func clampFontSize(size float32) float32 {
 	}
 	return size
 }
+
+func clampLetterSpacing(spacing float32) float32 {
+	if spacing < MinLetterSpacing {
+		return MinLetterSpacing
+	}
+	if spacing > MaxLetterSpacing {
+		return MaxLetterSpacing
+	}
+	return spacing
+}
+
+func clampMargin(margin int) int {
+	if margin < MinContentMargin {
+		return MinContentMargin
+	}
+	if margin > MaxContentMargin {
+		return MaxContentMargin
+	}
+	return margin
+}
+
+func roundStep(value float32) float32 {
+	return float32(math.Round(float64(value)*100) / 100)
+}
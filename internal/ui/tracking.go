@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+const maxAdjustedFonts = 16
+
+type adjustedFontKey struct {
+	name       string
+	tracking   float32
+	lineHeight float32
+}
+
+var adjustedFonts = struct {
+	sync.Mutex
+	resources map[adjustedFontKey]fyne.Resource
+}{resources: map[adjustedFontKey]fyne.Resource{}}
+
+func adjustedFont(font fyne.Resource, tracking, lineHeight float32) fyne.Resource {
+	if font == nil || (tracking == 0 && lineHeight <= 1) {
+		return font
+	}
+
+	key := adjustedFontKey{name: font.Name(), tracking: tracking, lineHeight: lineHeight}
+	adjustedFonts.Lock()
+	defer adjustedFonts.Unlock()
+	if resource, ok := adjustedFonts.resources[key]; ok {
+		return resource
+	}
+
+	data, name := font.Content(), font.Name()
+	if tracking != 0 {
+		tracked, ok := trackAdvances(data, tracking)
+		if !ok {
+			return font
+		}
+		data, name = tracked, fmt.Sprintf("%s+tracking%.3f", name, tracking)
+	}
+	if lineHeight > 1 {
+		led, ok := leadLines(data, lineHeight)
+		if !ok {
+			return font
+		}
+		data, name = led, fmt.Sprintf("%s+leading%.2f", name, lineHeight)
+	}
+	resource := fyne.NewStaticResource(name, data)
+	if len(adjustedFonts.resources) >= maxAdjustedFonts {
+		clear(adjustedFonts.resources)
+	}
+	adjustedFonts.resources[key] = resource
+	return resource
+}
+
+type fontTable struct {
+	offset int
+	length int
+}
+
+func fontTables(font []byte) (map[string]fontTable, bool) {
+	if len(font) < 12 {
+		return nil, false
+	}
+	tables := map[string]fontTable{}
+	count := int(binary.BigEndian.Uint16(font[4:6]))
+	for index := 0; index < count; index++ {
+		record := 12 + index*16
+		if record+16 > len(font) {
+			return nil, false
+		}
+		table := fontTable{
+			offset: int(binary.BigEndian.Uint32(font[record+8 : record+12])),
+			length: int(binary.BigEndian.Uint32(font[record+12 : record+16])),
+		}
+		if table.offset+table.length > len(font) {
+			return nil, false
+		}
+		tables[string(font[record:record+4])] = table
+	}
+	return tables, true
+}
+
+func trackAdvances(font []byte, tracking float32) ([]byte, bool) {
+	tables, ok := fontTables(font)
+	if !ok {
+		return nil, false
+	}
+	head, hhea, hmtx := tables["head"], tables["hhea"], tables["hmtx"]
+	if head.length < 20 || hhea.length < 36 || hmtx.length == 0 {
+		return nil, false
+	}
+	unitsPerEm := binary.BigEndian.Uint16(font[head.offset+18:])
+	metrics := int(binary.BigEndian.Uint16(font[hhea.offset+34:]))
+	if unitsPerEm == 0 || metrics*4 > hmtx.length {
+		return nil, false
+	}
+	delta := int(math.Round(float64(tracking) * float64(unitsPerEm)))
+
+	tracked := append([]byte(nil), font...)
+	for index := 0; index < metrics; index++ {
+		advance := tracked[hmtx.offset+index*4:]
+		width := int(binary.BigEndian.Uint16(advance))
+		if width == 0 {
+			continue
+		}
+		width += delta
+		if width < 0 {
+			width = 0
+		}
+		if width > math.MaxUint16 {
+			width = math.MaxUint16
+		}
+		binary.BigEndian.PutUint16(advance, uint16(width))
+	}
+	return tracked, true
+}
+
+func leadLines(font []byte, lineHeight float32) ([]byte, bool) {
+	tables, ok := fontTables(font)
+	if !ok {
+		return nil, false
+	}
+	hhea := tables["hhea"]
+	if hhea.length < 36 {
+		return nil, false
+	}
+
+	led := append([]byte(nil), font...)
+	addLeading(led[hhea.offset+4:], lineHeight)
+	if os2 := tables["OS/2"]; os2.length >= 74 {
+		addLeading(led[os2.offset+68:], lineHeight)
+	}
+	return led, true
+}
+
+func addLeading(metrics []byte, lineHeight float32) {
+	ascender := int(int16(binary.BigEndian.Uint16(metrics[0:])))
+	descender := int(int16(binary.BigEndian.Uint16(metrics[2:])))
+	lineGap := int(int16(binary.BigEndian.Uint16(metrics[4:])))
+	extra := int(math.Round(float64(lineHeight-1) * float64(ascender-descender+lineGap)))
+	if extra <= 0 {
+		return
+	}
+	binary.BigEndian.PutUint16(metrics[0:], uint16(clampInt16(ascender+(extra+1)/2)))
+	binary.BigEndian.PutUint16(metrics[2:], uint16(clampInt16(descender-extra/2)))
+}
+
+func clampInt16(value int) int16 {
+	return int16(max(math.MinInt16, min(math.MaxInt16, value)))
+}
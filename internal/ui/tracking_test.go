@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"grompt/assets"
+	"grompt/internal/content"
+)
+
+func glyphAdvances(t *testing.T, data []byte, text string) ([]int, int) {
+	t.Helper()
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		t.Fatalf("parse tracked font: %v", err)
+	}
+	var buffer sfnt.Buffer
+	unitsPerEm := int(parsed.UnitsPerEm())
+	advances := make([]int, 0, len(text))
+	for _, r := range text {
+		index, err := parsed.GlyphIndex(&buffer, r)
+		if err != nil || index == 0 {
+			t.Fatalf("glyph for %q: %v", r, err)
+		}
+		advance, err := parsed.GlyphAdvance(&buffer, index, fixed.I(unitsPerEm), font.HintingNone)
+		if err != nil {
+			t.Fatalf("advance for %q: %v", r, err)
+		}
+		advances = append(advances, advance.Round())
+	}
+	return advances, unitsPerEm
+}
+
+func TestTrackAdvancesBundledFonts(t *testing.T) {
+	const text = "aW .i"
+
+	tests := []struct {
+		name     string
+		family   string
+		tracking float32
+	}{
+		{name: "wider", family: "go", tracking: 0.05},
+		{name: "tighter", family: "go", tracking: -0.02},
+		{name: "monospace", family: "go-mono", tracking: 0.1},
+		{name: "condensed", family: "dejavu-condensed", tracking: 0.03},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			family, ok := assets.LookupFontFamily(tt.family)
+			if !ok {
+				t.Fatalf("bundled family %q is missing", tt.family)
+			}
+			original := family.Regular.Content()
+			tracked, ok := trackAdvances(original, tt.tracking)
+			if !ok {
+				t.Fatal("trackAdvances rejected a bundled font")
+			}
+			if len(tracked) != len(original) {
+				t.Fatalf("tracked font is %d bytes, want %d", len(tracked), len(original))
+			}
+
+			before, unitsPerEm := glyphAdvances(t, original, text)
+			after, _ := glyphAdvances(t, tracked, text)
+			delta := int(math.Round(float64(tt.tracking) * float64(unitsPerEm)))
+			for index, r := range text {
+				if after[index] != before[index]+delta {
+					t.Fatalf("advance of %q = %d, want %d%+d", r, after[index], before[index], delta)
+				}
+			}
+		})
+	}
+}
+
+func TestTrackAdvancesRejectsInvalidFonts(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty"},
+		{name: "short", data: []byte{0x00, 0x01, 0x00, 0x00}},
+		{name: "truncated tables", data: []byte{0x00, 0x01, 0x00, 0x00, 0x00, 0x05, 0, 0, 0, 0, 0, 0}},
+		{name: "no metrics", data: testFontData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := trackAdvances(tt.data, 0.05); ok {
+				t.Fatal("trackAdvances accepted invalid font data")
+			}
+		})
+	}
+}
+
+func TestTrackedFontCachesResources(t *testing.T) {
+	family, _ := assets.LookupFontFamily("go")
+	invalid := fyne.NewStaticResource("invalid.ttf", testFontData)
+
+	if got := adjustedFont(family.Regular, 0, 1); got != family.Regular {
+		t.Fatal("zero tracking should keep the original font")
+	}
+	if got := adjustedFont(invalid, 0.05, 1); got != invalid {
+		t.Fatal("unparseable fonts should be returned unchanged")
+	}
+	first := adjustedFont(family.Regular, 0.05, 1)
+	if first == family.Regular {
+		t.Fatal("tracking did not produce a new font")
+	}
+	if second := adjustedFont(family.Regular, 0.05, 1); second != first {
+		t.Fatal("tracked fonts should be cached")
+	}
+}
+
+func TestAdjustedFontCacheIsBounded(t *testing.T) {
+	family, _ := assets.LookupFontFamily("go")
+	for step := 1; step <= 3*maxAdjustedFonts; step++ {
+		adjustedFont(family.Regular, float32(step)/1000, 1)
+		adjustedFonts.Lock()
+		size := len(adjustedFonts.resources)
+		adjustedFonts.Unlock()
+		if size > maxAdjustedFonts {
+			t.Fatalf("cache holds %d fonts after %d adjustments, want at most %d", size, step, maxAdjustedFonts)
+		}
+	}
+}
+
+func TestLeadLinesBundledFonts(t *testing.T) {
+	tests := []struct {
+		name       string
+		family     string
+		lineHeight float32
+	}{
+		{name: "relaxed", family: "go", lineHeight: 1.5},
+		{name: "double", family: "go", lineHeight: 2},
+		{name: "monospace", family: "go-mono", lineHeight: 1.4},
+		{name: "condensed", family: "dejavu-condensed", lineHeight: 2.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			family, ok := assets.LookupFontFamily(tt.family)
+			if !ok {
+				t.Fatalf("bundled family %q is missing", tt.family)
+			}
+			original := family.Regular.Content()
+			led, ok := leadLines(original, tt.lineHeight)
+			if !ok {
+				t.Fatal("leadLines rejected a bundled font")
+			}
+
+			before, after := fontMetrics(t, original), fontMetrics(t, led)
+			want := float64(before.Height.Round()) * float64(tt.lineHeight)
+			if got := float64(after.Height.Round()); math.Abs(got-want) > 2 {
+				t.Fatalf("line height = %v units, want %v", got, want)
+			}
+			if after.Ascent <= before.Ascent || after.Descent <= before.Descent {
+				t.Fatalf("leading should be split above and below: %+v -> %+v", before, after)
+			}
+		})
+	}
+}
+
+func fontMetrics(t *testing.T, data []byte) font.Metrics {
+	t.Helper()
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		t.Fatalf("parse font: %v", err)
+	}
+	metrics, err := parsed.Metrics(nil, fixed.I(int(parsed.UnitsPerEm())), font.HintingNone)
+	if err != nil {
+		t.Fatalf("font metrics: %v", err)
+	}
+	return metrics
+}
+
+func TestLineHeightLeadsWrappedParagraphs(t *testing.T) {
+	doc, err := content.Parse([]byte(strings.Repeat("Good evening and welcome to the show. ", 8)), content.FormatText)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		lineHeight float32
+	}{
+		{name: "single", lineHeight: 1},
+		{name: "relaxed", lineHeight: 1.5},
+		{name: "double", lineHeight: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := test.NewTempApp(t)
+			typography := NewTypographyTheme(DefaultContentFontSize)
+			typography.SetLineHeight(tt.lineHeight)
+			app.Settings().SetTheme(typography)
+
+			object, _ := content.RenderDocument(doc, content.DefaultRenderOptions())
+			richText := object.(*widget.RichText)
+			richText.Resize(fyne.NewSize(480, richText.MinSize().Height))
+
+			rows := map[float32]bool{}
+			for _, object := range test.WidgetRenderer(richText).Objects() {
+				if text, ok := object.(*canvas.Text); ok && strings.TrimSpace(text.Text) != "" {
+					rows[text.Position().Y] = true
+				}
+			}
+			if len(rows) < 3 {
+				t.Fatalf("got %d rows, want the paragraph to wrap", len(rows))
+			}
+
+			lines := float32(len(rows))
+			natural := fyne.MeasureText("M", typography.BodySize(), fyne.TextStyle{}).Height
+			want := 2*theme.Size(theme.SizeNameInnerPadding) + lines*natural*tt.lineHeight
+			if got := richText.MinSize().Height; math.Abs(float64(got-want)) > float64(lines) {
+				t.Fatalf("paragraph of %v lines is %v tall, want %v", lines, got, want)
+			}
+		})
+	}
+}
@@ -3,32 +3,57 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"math"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"grompt/assets"
 	appconfig "grompt/internal/config"
 	"grompt/internal/content"
 	"grompt/internal/input"
+	"grompt/internal/remote"
 	scrollengine "grompt/internal/scroll"
 )
 
 const (
-	appName        = "grompt"
-	defaultWidth   = 1024
-	defaultHeight  = 768
-	initialMessage = "Open an HTML or Markdown file to start."
+	appName           = "grompt"
+	talentWindowTitle = appName + " - Talent"
+	defaultWidth      = 1024
+	defaultHeight     = 768
+	initialMessage    = "Open a script file to start."
+	rewindLines       = 3
+	countdownTextSize = 160
+	resumeThreshold   = 0.01
 )
 
-func Run() error {
-	configPath, pathErr := appconfig.DefaultPath()
-	configWarnings := make([]string, 0)
+type Options struct {
+	ConfigPath   string
+	File         string
+	Overrides    appconfig.FileSettings
+	FullScreen   bool
+	StartPlaying bool
+	Countdown    time.Duration
+	Warnings     []string
+}
+
+func Run(options Options) error {
+	configPath := options.ConfigPath
+	var pathErr error
+	if configPath == "" {
+		configPath, pathErr = appconfig.DefaultPath()
+	}
+	configWarnings := append([]string(nil), options.Warnings...)
 	if pathErr != nil {
 		configWarnings = append(configWarnings, fmt.Sprintf("cannot resolve config path: %v", pathErr))
 	}
@@ -42,49 +67,28 @@ func Run() error {
 			configWarnings = append(configWarnings, fmt.Sprintf("cannot read config file: %v", loadErr))
 		}
 	}
+	baseline, _ := resolveSettings(loadedSettings)
+	loadedSettings = loadedSettings.Merge(options.Overrides)
 
-	initialSpeed := scrollengine.DefaultSpeed
-	if loadedSettings.Speed != nil {
-		next := *loadedSettings.Speed
-		if next < scrollengine.DefaultMinSpeed || next > scrollengine.DefaultMaxSpeed {
-			configWarnings = append(configWarnings, fmt.Sprintf("speed %.0f out of range, clamped", next))
-		}
-		if next < scrollengine.DefaultMinSpeed {
-			next = scrollengine.DefaultMinSpeed
-		}
-		if next > scrollengine.DefaultMaxSpeed {
-			next = scrollengine.DefaultMaxSpeed
-		}
-		initialSpeed = next
-	}
-
-	initialFontSize := DefaultContentFontSize
-	if loadedSettings.FontSize != nil {
-		next := *loadedSettings.FontSize
-		normalized := clampFontSize(next)
-		if normalized != next {
-			configWarnings = append(configWarnings, fmt.Sprintf("font_size %.0f out of range, clamped", next))
-		}
-		initialFontSize = normalized
-	}
-
-	initialWordSpacing := content.DefaultRenderOptions().WordSpacing
-	if loadedSettings.WordSpacing != nil {
-		next := *loadedSettings.WordSpacing
-		normalized := content.NormalizeWordSpacing(next)
-		if normalized != next {
-			configWarnings = append(configWarnings, fmt.Sprintf("word_spacing %d out of range, clamped", next))
-		}
-		initialWordSpacing = normalized
-	}
+	resolved, resolveWarnings := resolveSettings(loadedSettings)
+	configWarnings = append(configWarnings, resolveWarnings...)
+	keymap := resolved.keymap
+	currentProfile := loadedSettings.Profile
+	profiles := loadedSettings.Profiles
 
 	a := app.NewWithID("com.grompt.app")
 	a.SetIcon(assets.AppIconResource())
-	typographyTheme := NewTypographyTheme(initialFontSize)
+	typographyTheme := NewTypographyTheme(resolved.fontSize)
+	typographyTheme.SetColors(resolved.colorScheme, resolved.foreground, resolved.background)
+	typographyTheme.setFontFamily(resolved.font)
+	typographyTheme.SetLineHeight(resolved.lineHeight)
+	typographyTheme.SetLetterSpacing(resolved.letterSpacing)
+	typographyTheme.SetMargin(resolved.margin)
 	a.Settings().SetTheme(typographyTheme)
 
 	w := a.NewWindow(appName)
 	w.Resize(fyne.NewSize(defaultWidth, defaultHeight))
+	w.SetMaster()
 
 	initialContent := widget.NewRichTextFromMarkdown(initialMessage)
 	initialContent.Wrapping = fyne.TextWrapWord
@@ -92,54 +96,179 @@ func Run() error {
 
 	scroll := container.NewScroll(initialContent)
 	scroll.SetMinSize(fyne.NewSize(640, 400))
-	scrollWithFade := NewScrollWithFade(scroll, func() float32 {
+	scrollWithFade := NewScrollWithFade(scroll, resolved.guide, func() float32 {
 		return estimatedLineHeight(typographyTheme.BodySize())
-	})
+	}, typographyTheme.Margin)
+	scrollWithFade.SetMirror(resolved.mirror, resolved.flip)
+
+	previewScroll := container.NewScroll(widget.NewLabel(""))
+	preview := NewScrollWithFade(previewScroll, resolved.guide, func() float32 {
+		return estimatedLineHeight(typographyTheme.BodySize())
+	}, typographyTheme.Margin)
+	viewport := container.NewStack(scrollWithFade)
+	var talentWindow fyne.Window
+
+	speedUnit := resolved.unit
+	targetWPM := resolved.wpm
+	documentWords := 0
 
 	var engine *scrollengine.Engine
-	engine = scrollengine.NewEngine(func(delta float64) {
-		fyne.Do(func() {
-			if scroll.Content == nil {
-				return
-			}
+	var schedule *scrollengine.Schedule
+	var documentCues []content.Cue
+	var documentSections []content.Section
+	var sectionPositions []float32
+	anchorLayoutSize := fyne.Size{}
+	layoutAnchors := func() {
+		richText, th := mirrorSource(scroll.Content)
+		if richText == nil {
+			return
+		}
+		size := richText.Size()
+		if size.Width <= 0 || size.Height <= 0 || size == anchorLayoutSize {
+			return
+		}
+		anchorLayoutSize = size
 
-			maxOffset := scroll.Content.MinSize().Height - scroll.Size().Height
-			if maxOffset <= 0 {
-				engine.Pause()
-				return
-			}
+		tops := segmentTops(richText, th, size.Width)
+		sectionPositions = make([]float32, len(documentSections))
+		for i, section := range documentSections {
+			sectionPositions[i] = tops[section.Segment] / size.Height
+		}
+		cues := make([]scrollengine.Cue, 0, len(documentCues))
+		for _, cue := range documentCues {
+			cues = append(cues, scrollengine.Cue{
+				Offset: float64(tops[cue.Segment]),
+				Kind:   scrollengine.CueKind(cue.Kind),
+				Speed:  cue.Speed,
+				Wait:   cue.Wait,
+			})
+		}
+		engine.SetCues(cues)
+	}
 
-			nextOffset := scroll.Offset.Y + float32(delta)
-			if nextOffset >= maxOffset {
-				nextOffset = maxOffset
-				engine.Pause()
-			}
-			scroll.ScrollToOffset(fyne.NewPos(0, nextOffset))
-		})
-	})
+	var controls *Controls
+	wpmLayoutHeight := float32(0)
+	wpmLayoutTarget := 0.0
+	wpmLayoutSpeed := 0.0
+	syncWPMSpeed := func() {
+		if speedUnit != scrollengine.UnitWPM || schedule != nil || scroll.Content == nil {
+			wpmLayoutHeight = 0
+			controls.SetEffectiveSpeed(0)
+			return
+		}
+		height := documentHeight(scroll)
+		if height == wpmLayoutHeight && targetWPM == wpmLayoutTarget && engine.Speed() == wpmLayoutSpeed {
+			return
+		}
+		speed, ok := scrollengine.WPMToSpeed(targetWPM, documentWords, height)
+		if !ok {
+			return
+		}
+		wpmLayoutHeight, wpmLayoutTarget = height, targetWPM
+		wpmLayoutSpeed = engine.SetSpeed(speed)
+		effective, _ := scrollengine.SpeedToWPM(wpmLayoutSpeed, documentWords, height)
+		controls.SetEffectiveSpeed(effective)
+	}
+
+	engine = scrollengine.NewFrameEngine(func(delta float64) {
+		if scroll.Content == nil {
+			return
+		}
+		syncWPMSpeed()
+		layoutAnchors()
+
+		maxOffset := scroll.Content.MinSize().Height - scroll.Size().Height
+		if maxOffset <= 0 {
+			engine.PauseImmediately()
+			return
+		}
+
+		previousOffset := scroll.Offset.Y
+		nextOffset := previousOffset + float32(delta)
+		remaining := maxOffset - nextOffset
+		if delta < 0 {
+			remaining = nextOffset
+		}
+		if engine.IsPlaying() && float64(remaining) <= engine.StoppingDistance() {
+			engine.PauseWithin(float64(remaining))
+		}
+		if delta > 0 && nextOffset >= maxOffset-scrollengine.ArrivalTolerance {
+			nextOffset = maxOffset
+			engine.PauseImmediately()
+		}
+		if nextOffset <= 0 {
+			nextOffset = 0
+			engine.PauseImmediately()
+		}
+		scroll.ScrollToOffset(fyne.NewPos(0, nextOffset))
+
+		readingLine := scroll.Size().Height * scrollWithFade.Guide().Ratio()
+		engine.CrossCues(float64(previousOffset+readingLine), float64(nextOffset+readingLine))
+	}, nil)
 	defer engine.Stop()
-	engine.SetSpeed(initialSpeed)
+	engine.SetEasing(resolved.easing, resolved.ramp)
+	engine.SetSpeed(resolved.speed)
 
 	var settingsWriter *appconfig.AsyncWriter
+	var stateWriter *appconfig.AsyncWriter
+	documentState := appconfig.State{}
 	if pathErr == nil {
 		settingsWriter = appconfig.NewAsyncWriter(configPath)
+		settingsWriter.SetBaseline(baseline.settings(loadedSettings.Profile))
 		defer settingsWriter.Close()
+
+		statePath := appconfig.StatePath(configPath)
+		state, stateErr := appconfig.LoadState(statePath)
+		if stateErr != nil {
+			configWarnings = append(configWarnings, fmt.Sprintf("cannot read state file: %v", stateErr))
+		}
+		documentState = state
+		stateWriter = appconfig.NewAsyncWriter(statePath)
+		defer stateWriter.Close()
 	}
 
-	var controls *Controls
-	wordSpacing := initialWordSpacing
-	var loadedData []byte
-	var loadedFormat content.Format
+	wordSpacing := resolved.wordSpacing
+	var loadedDocument *content.Document
+	var remoteSections []remote.Section
 	var loadedFileName string
+	var documentPath string
+	var documentKey string
+	documentTracked := false
+	var lastRemembered appconfig.DocumentState
+
+	renderOptions := func() content.RenderOptions {
+		return content.RenderOptions{
+			WordSpacing: wordSpacing,
+		}
+	}
 
 	saveSettings := func() {
 		if settingsWriter == nil {
 			return
 		}
+		easing, ramp := engine.Easing()
+		mirror, flip := scrollWithFade.Mirror()
+		guide := scrollWithFade.Guide()
 		settingsWriter.Save(appconfig.Settings{
-			Speed:       engine.Speed(),
-			FontSize:    typographyTheme.BodySize(),
-			WordSpacing: wordSpacing,
+			Profile:       currentProfile,
+			Speed:         engine.Speed(),
+			FontSize:      typographyTheme.BodySize(),
+			Font:          typographyTheme.FontName(),
+			WordSpacing:   wordSpacing,
+			LineHeight:    typographyTheme.LineHeight(),
+			LetterSpacing: typographyTheme.LetterSpacing(),
+			Margin:        typographyTheme.Margin(),
+			Easing:        string(easing),
+			RampMillis:    int(ramp / time.Millisecond),
+			SpeedUnit:     string(speedUnit),
+			WPM:           targetWPM,
+			Mirror:        mirror,
+			Flip:          flip,
+			ColorScheme:   string(typographyTheme.ColorScheme()),
+			GuidePosition: string(guide.Position),
+			GuideLines:    guide.Lines,
+			GuideFade:     guide.Fade,
+			GuideMarker:   string(guide.Marker),
 		})
 	}
 
@@ -150,25 +279,171 @@ func Run() error {
 		}
 		scroll.Refresh()
 		scrollWithFade.Refresh()
+		if talentWindow != nil {
+			previewScroll.Refresh()
+			preview.Refresh()
+		}
 	}
 
-	renderCurrentDocument := func() error {
-		if len(loadedData) == 0 {
-			return nil
+	renderPreview := func() {
+		if loadedDocument == nil || talentWindow == nil {
+			return
 		}
+		rendered, _ := content.RenderDocument(loadedDocument, renderOptions())
+		previewScroll.Content = newRunout(previewScroll, scrollWithFade.Guide().Ratio, rendered)
+		previewScroll.Refresh()
+		followScroll(scroll, previewScroll, scrollWithFade.Guide().Ratio())
+	}
 
-		rendered, renderErr := content.RenderWithOptions(loadedData, loadedFormat, content.RenderOptions{
-			WordSpacing: wordSpacing,
-		})
-		if renderErr != nil {
-			return renderErr
+	scrollToFraction := func(fraction float32) {
+		if scroll.Content == nil {
+			return
+		}
+		offset := fraction*documentHeight(scroll) - scroll.Size().Height*scrollWithFade.Guide().Ratio()
+		maxOffset := scroll.Content.MinSize().Height - scroll.Size().Height
+		if offset > maxOffset {
+			offset = maxOffset
 		}
+		if offset < 0 {
+			offset = 0
+		}
+		scroll.ScrollToOffset(fyne.NewPos(0, offset))
+	}
+
+	renderDocumentAt := func(position float32) {
+		if loadedDocument == nil {
+			return
+		}
+
+		rendered, cues := content.RenderDocument(loadedDocument, renderOptions())
 
-		scroll.Content = rendered
-		scroll.ScrollToOffset(fyne.Position{})
+		scroll.Content = newRunout(scroll, scrollWithFade.Guide().Ratio, rendered)
+		documentWords = loadedDocument.WordCount()
+		documentCues = cues
+		documentSections = content.Outline(loadedDocument)
+		remoteSections = make([]remote.Section, 0, len(documentSections))
+		for _, section := range documentSections {
+			remoteSections = append(remoteSections, remote.Section{Title: section.Title, Level: section.Level})
+		}
+		anchorLayoutSize = fyne.Size{}
+		wpmLayoutHeight = 0
+		scrollToFraction(position)
+		renderPreview()
 		refreshViewport()
+		syncWPMSpeed()
+		layoutAnchors()
 		controls.SetFileName(loadedFileName)
-		return nil
+	}
+
+	renderCurrentDocument := func() {
+		renderDocumentAt(readingLineFraction(scroll, scrollWithFade.Guide().Ratio()))
+	}
+
+	var applyDocumentState func(remembered appconfig.DocumentState)
+
+	var fileWatcher *content.FileWatcher
+	defer func() {
+		if fileWatcher != nil {
+			_ = fileWatcher.Close()
+		}
+	}()
+	var reloadWarning *widget.PopUp
+
+	reloadDocument := func(path string) {
+		if path != documentPath || loadedDocument == nil {
+			return
+		}
+
+		data, format, reloadErr := content.LoadFromPath(path)
+		var doc *content.Document
+		if reloadErr == nil {
+			doc, reloadErr = content.Parse(data, format)
+		}
+		if reloadWarning != nil {
+			reloadWarning.Hide()
+			reloadWarning = nil
+		}
+		if reloadErr != nil {
+			reloadWarning = showWarningOverlay(w, "Reload failed",
+				fmt.Sprintf("%s changed but could not be reloaded, so the previous version stays on screen:\n%v", loadedFileName, reloadErr))
+			return
+		}
+
+		key := appconfig.DocumentKey(path, data)
+		if key == documentKey {
+			return
+		}
+		position := content.MapPosition(loadedDocument, doc, readingLineFraction(scroll, scrollWithFade.Guide().Ratio()))
+		loadedDocument = doc
+		documentKey = key
+		renderDocumentAt(position)
+	}
+
+	watchDocument := func(path string) {
+		if fileWatcher != nil {
+			_ = fileWatcher.Close()
+			fileWatcher = nil
+		}
+		watcher, err := content.WatchFile(path, func() {
+			fyne.Do(func() { reloadDocument(path) })
+		})
+		if err == nil {
+			fileWatcher = watcher
+		}
+	}
+
+	loadPath := func(path string) {
+		data, format, loadErr := content.LoadFromPath(path)
+		if loadErr != nil {
+			if errors.Is(loadErr, content.ErrUnsupportedFileType) {
+				dialog.ShowInformation("Unsupported file", "Supported extensions are "+content.SupportedExtensionsText()+".", w)
+				return
+			}
+			dialog.ShowError(loadErr, w)
+			return
+		}
+
+		doc, parseErr := content.Parse(data, format)
+		if parseErr != nil {
+			dialog.ShowError(parseErr, w)
+			return
+		}
+
+		loadedDocument = doc
+		loadedFileName = filepath.Base(path)
+		documentPath = path
+		if absolute, absErr := filepath.Abs(path); absErr == nil {
+			documentPath = absolute
+		}
+		documentKey = appconfig.DocumentKey(documentPath, data)
+		documentTracked = false
+		if schedule != nil {
+			schedule.Reset()
+		}
+		if reloadWarning != nil {
+			reloadWarning.Hide()
+			reloadWarning = nil
+		}
+
+		renderDocumentAt(0)
+		watchDocument(documentPath)
+
+		remembered, ok := documentState.Documents[documentKey]
+		if !ok || remembered.Position <= float64(readingLineFraction(scroll, scrollWithFade.Guide().Ratio()))+resumeThreshold {
+			documentTracked = true
+			return
+		}
+		key := documentKey
+		message := fmt.Sprintf("Resume %s at %.0f%% with the speed and text settings used last time?", loadedFileName, remembered.Position*100)
+		dialog.ShowConfirm("Resume script", message, func(resume bool) {
+			if key != documentKey {
+				return
+			}
+			if resume {
+				applyDocumentState(remembered)
+			}
+			documentTracked = true
+		}, w)
 	}
 
 	openFile := func() {
@@ -182,71 +457,630 @@ func Run() error {
 			}
 			defer reader.Close()
 
-			path := reader.URI().Path()
-			data, format, loadErr := content.LoadFromPath(path)
-			if loadErr != nil {
-				if errors.Is(loadErr, content.ErrUnsupportedFileType) {
-					dialog.ShowInformation("Unsupported file", "Supported extensions are .md, .markdown, .html and .htm.", w)
-					return
-				}
-				dialog.ShowError(loadErr, w)
-				return
-			}
-
-			loadedData = data
-			loadedFormat = format
-			loadedFileName = filepath.Base(path)
-
-			if renderErr := renderCurrentDocument(); renderErr != nil {
-				dialog.ShowError(renderErr, w)
-			}
+			loadPath(reader.URI().Path())
 		}, w)
 
-		fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".md", ".markdown", ".html", ".htm"}))
+		fileDialog.SetFilter(storage.NewExtensionFileFilter(content.SupportedExtensions()))
 		fileDialog.Show()
 	}
 
-	applyFontSizeChange := func() {
+	applyTypographyChange := func() {
 		a.Settings().SetTheme(typographyTheme)
-		if len(loadedData) == 0 {
+		if loadedDocument == nil {
 			refreshViewport()
 			return
 		}
-		if err := renderCurrentDocument(); err != nil {
-			dialog.ShowError(err, w)
-			return
-		}
+		renderCurrentDocument()
 		a.Settings().SetTheme(typographyTheme)
 	}
 
 	increaseFontSize := func() {
 		typographyTheme.IncreaseBodySize()
-		applyFontSizeChange()
+		applyTypographyChange()
 		saveSettings()
 	}
 
 	decreaseFontSize := func() {
 		typographyTheme.DecreaseBodySize()
-		applyFontSizeChange()
+		applyTypographyChange()
+		saveSettings()
+	}
+
+	increaseLineHeight := func() {
+		typographyTheme.IncreaseLineHeight()
+		applyTypographyChange()
+		saveSettings()
+	}
+
+	decreaseLineHeight := func() {
+		typographyTheme.DecreaseLineHeight()
+		applyTypographyChange()
+		saveSettings()
+	}
+
+	increaseLetterSpacing := func() {
+		typographyTheme.IncreaseLetterSpacing()
+		applyTypographyChange()
+		saveSettings()
+	}
+
+	decreaseLetterSpacing := func() {
+		typographyTheme.DecreaseLetterSpacing()
+		applyTypographyChange()
+		saveSettings()
+	}
+
+	increaseMargin := func() {
+		typographyTheme.IncreaseMargin()
+		applyTypographyChange()
+		saveSettings()
+	}
+
+	decreaseMargin := func() {
+		typographyTheme.DecreaseMargin()
+		applyTypographyChange()
 		saveSettings()
 	}
 
 	changeWordSpacing := func(next int) {
 		wordSpacing = content.NormalizeWordSpacing(next)
-		if err := renderCurrentDocument(); err != nil {
+		renderCurrentDocument()
+		saveSettings()
+	}
+
+	displayedSpeed := func() float64 {
+		if speedUnit != scrollengine.UnitWPM {
+			return engine.Speed()
+		}
+		if schedule != nil && scroll.Content != nil {
+			if wpm, ok := scrollengine.SpeedToWPM(engine.Speed(), documentWords, documentHeight(scroll)); ok {
+				return wpm
+			}
+		}
+		return targetWPM
+	}
+
+	updateSchedule := func() {
+		if schedule == nil || scroll.Content == nil {
+			return
+		}
+
+		now := time.Now()
+		position := float64(scroll.Offset.Y)
+		end := float64(scroll.Content.MinSize().Height - scroll.Size().Height)
+		if engine.IsPlaying() && schedule.NeedsPlan(now) {
+			engine.SetSpeed(schedule.Plan(now, position, end, engine.StoppingTime()))
+			controls.SetSpeed(displayedSpeed())
+		}
+		required := schedule.Required(now, position, end)
+		drift := schedule.Drift(now, position, end, engine.Speed())
+		controls.SetSchedule(formatSchedule(schedule.Remaining(now), drift, schedule.Started(), required))
+	}
+
+	nudgeSchedule := func() {
+		if schedule != nil {
+			schedule.Nudge(time.Now())
+		}
+	}
+
+	play := func() {
+		engine.Play()
+		if schedule != nil {
+			schedule.Start(time.Now())
+			schedule.Replan()
+		}
+	}
+
+	togglePlayPause := func() {
+		if engine.IsPlaying() {
+			engine.Pause()
+			return
+		}
+		play()
+	}
+
+	setTargetDuration := func(value string) {
+		if strings.TrimSpace(value) == "" {
+			schedule = nil
+			controls.SetSchedule("")
+			syncWPMSpeed()
+			controls.SetSpeed(displayedSpeed())
+			return
+		}
+
+		target, err := scrollengine.ParseTargetDuration(value)
+		if err != nil {
 			dialog.ShowError(err, w)
 			return
 		}
+		schedule = scrollengine.NewSchedule(target)
+		if engine.IsPlaying() {
+			schedule.Start(time.Now())
+		}
+		updateSchedule()
+	}
+
+	showTargetDurationDialog := func() {
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder("3:30")
+		if schedule != nil {
+			entry.SetText(scrollengine.FormatClock(schedule.Target()))
+		}
+		dialog.ShowForm("Target duration", "Set", "Cancel", []*widget.FormItem{
+			widget.NewFormItem("Finish in", entry),
+		}, func(confirmed bool) {
+			if confirmed {
+				setTargetDuration(entry.Text)
+			}
+		}, w)
+	}
+
+	engine.SetOnCue(func(cue scrollengine.Cue) {
+		if cue.Kind == scrollengine.CueSpeed && speedUnit == scrollengine.UnitWPM {
+			targetWPM = scrollengine.ClampWPM(cue.Speed)
+			syncWPMSpeed()
+		}
+		controls.SetSpeed(displayedSpeed())
+	})
+
+	speedUp := func() {
+		if speedUnit == scrollengine.UnitWPM && schedule == nil {
+			targetWPM = scrollengine.ClampWPM(targetWPM + scrollengine.WPMStep)
+			syncWPMSpeed()
+		} else {
+			engine.SpeedUp()
+			nudgeSchedule()
+		}
+		controls.SetSpeed(displayedSpeed())
+		saveSettings()
+	}
+
+	speedDown := func() {
+		if speedUnit == scrollengine.UnitWPM && schedule == nil {
+			targetWPM = scrollengine.ClampWPM(targetWPM - scrollengine.WPMStep)
+			syncWPMSpeed()
+		} else {
+			engine.SpeedDown()
+			nudgeSchedule()
+		}
+		controls.SetSpeed(displayedSpeed())
+		saveSettings()
+	}
+
+	toggleSpeedUnit := func() {
+		if speedUnit == scrollengine.UnitWPM {
+			speedUnit = scrollengine.UnitPixels
+		} else {
+			speedUnit = scrollengine.UnitWPM
+			if scroll.Content != nil {
+				if wpm, ok := scrollengine.SpeedToWPM(engine.Speed(), documentWords, documentHeight(scroll)); ok {
+					targetWPM = scrollengine.ClampWPM(math.Round(wpm/scrollengine.WPMStep) * scrollengine.WPMStep)
+				}
+			}
+			syncWPMSpeed()
+		}
+		controls.SetSpeedUnit(displayedSpeed(), speedUnit)
+		saveSettings()
+	}
+
+	rewind := func() {
+		nextOffset := scroll.Offset.Y - rewindLines*estimatedLineHeight(typographyTheme.BodySize())
+		if nextOffset < 0 {
+			nextOffset = 0
+		}
+		scroll.ScrollToOffset(fyne.NewPos(0, nextOffset))
+	}
+
+	toggleReverse := func() {
+		controls.SetReverse(engine.ToggleReverse())
+	}
+
+	cycleEasing := func() {
+		easing, ramp := engine.Easing()
+		switch easing {
+		case scrollengine.EasingNone:
+			easing = scrollengine.EasingLinear
+		case scrollengine.EasingLinear:
+			easing = scrollengine.EasingEaseInOut
+		default:
+			easing = scrollengine.EasingNone
+		}
+		engine.SetEasing(easing, ramp)
+		saveSettings()
+	}
+
+	setGuide := func(guide ReadingGuide) {
+		guide.Lines = NormalizeGuideLines(guide.Lines)
+		guide.Fade = NormalizeGuideFade(guide.Fade)
+		position := readingLineFraction(scroll, scrollWithFade.Guide().Ratio())
+		scrollWithFade.SetGuide(guide)
+		preview.SetGuide(guide)
+		if loadedDocument != nil {
+			scrollToFraction(position)
+		}
+		saveSettings()
+	}
+
+	setFont := func(name string) {
+		family, err := loadFontFamily(name)
+		if err != nil {
+			showConfigWarningOverlay(w, []string{fmt.Sprintf("cannot load font %q: %v", name, err)})
+			return
+		}
+		typographyTheme.setFontFamily(family)
+		applyTypographyChange()
+		saveSettings()
+	}
+
+	setColorScheme := func(scheme ColorScheme) {
+		typographyTheme.SetColorScheme(scheme)
+		refreshViewport()
+		saveSettings()
+	}
+
+	toggleMirror := func() {
+		mirror, flip := scrollWithFade.Mirror()
+		scrollWithFade.SetMirror(!mirror, flip)
+		saveSettings()
+	}
+
+	toggleFlip := func() {
+		mirror, flip := scrollWithFade.Mirror()
+		scrollWithFade.SetMirror(mirror, !flip)
+		saveSettings()
+	}
+
+	var keyActions input.KeyActions
+
+	closeTalentWindow := func() {
+		if talentWindow == nil {
+			return
+		}
+		closing := talentWindow
+		talentWindow = nil
+		closing.SetContent(widget.NewLabel(""))
+		viewport.Objects = []fyne.CanvasObject{scrollWithFade}
+		viewport.Refresh()
+		closing.Close()
+	}
+
+	openTalentWindow := func() {
+		if talentWindow != nil {
+			talentWindow.RequestFocus()
+			return
+		}
+
+		talentWindow = a.NewWindow(talentWindowTitle)
+		viewport.Objects = []fyne.CanvasObject{preview}
+		viewport.Refresh()
+		talentWindow.SetContent(scrollWithFade)
+		talentWindow.SetOnClosed(func() {
+			if talentWindow != nil {
+				talentWindow = nil
+				viewport.Objects = []fyne.CanvasObject{scrollWithFade}
+				viewport.Refresh()
+			}
+		})
+		input.BindTeleprompterKeys(talentWindow.Canvas(), keymap, keyActions)
+		talentWindow.SetFullScreen(true)
+		talentWindow.Show()
+		renderPreview()
+	}
+
+	toggleTalentWindow := func() {
+		if talentWindow != nil {
+			closeTalentWindow()
+			return
+		}
+		openTalentWindow()
+	}
+
+	toggleFullScreen := func() {
+		if talentWindow != nil {
+			talentWindow.SetFullScreen(!talentWindow.FullScreen())
+			return
+		}
+		w.SetFullScreen(!w.FullScreen())
+	}
+
+	setSpeed := func(value float64) {
+		if speedUnit == scrollengine.UnitWPM {
+			targetWPM = scrollengine.ClampWPM(value)
+			syncWPMSpeed()
+		} else {
+			engine.SetSpeed(value)
+			nudgeSchedule()
+		}
+		controls.SetSpeed(displayedSpeed())
 		saveSettings()
 	}
 
+	applyDocumentState = func(remembered appconfig.DocumentState) {
+		if remembered.FontSize > 0 {
+			typographyTheme.SetBodySize(remembered.FontSize)
+			a.Settings().SetTheme(typographyTheme)
+		}
+		wordSpacing = content.NormalizeWordSpacing(remembered.WordSpacing)
+		renderDocumentAt(float32(remembered.Position))
+		if unit, ok := scrollengine.ParseSpeedUnit(remembered.SpeedUnit); ok && unit != speedUnit {
+			speedUnit = unit
+			controls.SetSpeedUnit(displayedSpeed(), speedUnit)
+		}
+		if remembered.Speed > 0 {
+			setSpeed(remembered.Speed)
+		}
+		saveSettings()
+	}
+
+	rememberDocument := func() {
+		if stateWriter == nil || documentKey == "" || !documentTracked {
+			return
+		}
+		current := appconfig.DocumentState{
+			Path:        documentPath,
+			Position:    math.Round(float64(readingLineFraction(scroll, scrollWithFade.Guide().Ratio()))*1000) / 1000,
+			Speed:       math.Round(displayedSpeed()),
+			SpeedUnit:   string(speedUnit),
+			FontSize:    typographyTheme.BodySize(),
+			WordSpacing: wordSpacing,
+		}
+		if current == lastRemembered {
+			return
+		}
+		lastRemembered = current
+		current.UpdatedAt = time.Now()
+		documentState.Remember(documentKey, current)
+		stateWriter.SaveState(documentState)
+	}
+
+	jumpToSection := func(index int) {
+		layoutAnchors()
+		if index < 0 || index >= len(sectionPositions) {
+			return
+		}
+		scrollToFraction(sectionPositions[index])
+	}
+
+	jumpToAdjacentSection := func(forward bool) {
+		if scroll.Content == nil {
+			return
+		}
+		contentHeight := documentHeight(scroll)
+		if contentHeight <= 0 {
+			return
+		}
+		layoutAnchors()
+		index := adjacentSection(sectionPositions, readingLineFraction(scroll, scrollWithFade.Guide().Ratio()), 1/contentHeight, forward)
+		if index >= 0 {
+			jumpToSection(index)
+		}
+	}
+
+	resetToTop := func() {
+		scroll.ScrollToOffset(fyne.NewPos(0, 0))
+	}
+
+	var keymapHelp *widget.PopUp
+	toggleKeymapHelp := func() {
+		if keymapHelp != nil && keymapHelp.Visible() {
+			keymapHelp.Hide()
+			return
+		}
+		keymapHelp = newKeymapHelp(w.Canvas(), keymap)
+		keymapHelp.Show()
+	}
+
+	applySettings := func(settings appconfig.FileSettings) []string {
+		baseline, _ := resolveSettings(settings)
+		next, warnings := resolveSettings(settings.Merge(options.Overrides))
+		currentProfile = settings.Profile
+		profiles = settings.Profiles
+
+		engine.SetEasing(next.easing, next.ramp)
+		engine.SetSpeed(next.speed)
+		speedUnit = next.unit
+		targetWPM = next.wpm
+		syncWPMSpeed()
+		controls.SetSpeedUnit(displayedSpeed(), speedUnit)
+
+		input.UnbindTeleprompterKeys(w.Canvas(), keymap)
+		if talentWindow != nil {
+			input.UnbindTeleprompterKeys(talentWindow.Canvas(), keymap)
+		}
+		keymap = next.keymap
+		input.BindTeleprompterKeys(w.Canvas(), keymap, keyActions)
+		if talentWindow != nil {
+			input.BindTeleprompterKeys(talentWindow.Canvas(), keymap, keyActions)
+		}
+
+		typographyTheme.SetBodySize(next.fontSize)
+		typographyTheme.setFontFamily(next.font)
+		typographyTheme.SetLineHeight(next.lineHeight)
+		typographyTheme.SetLetterSpacing(next.letterSpacing)
+		typographyTheme.SetMargin(next.margin)
+		typographyTheme.SetColors(next.colorScheme, next.foreground, next.background)
+		wordSpacing = next.wordSpacing
+		scrollWithFade.SetMirror(next.mirror, next.flip)
+		scrollWithFade.SetGuide(next.guide)
+		preview.SetGuide(next.guide)
+		applyTypographyChange()
+		if settingsWriter != nil {
+			settingsWriter.SetBaseline(baseline.settings(currentProfile))
+		}
+
+		if next.remoteAddr != resolved.remoteAddr || next.remoteToken != resolved.remoteToken ||
+			next.oscAddr != resolved.oscAddr || next.oscFeedback != resolved.oscFeedback || next.scriptsDir != resolved.scriptsDir {
+			warnings = append(warnings, "remote, OSC and scripts_dir changes apply after a restart")
+		}
+		return warnings
+	}
+
+	switchProfile := func(name string) {
+		settings, warnings, err := appconfig.LoadProfile(configPath, name)
+		if err != nil {
+			showConfigWarningOverlay(w, append(warnings, fmt.Sprintf("cannot read config file: %v", err)))
+			return
+		}
+		warnings = append(warnings, applySettings(settings)...)
+		if settingsWriter != nil {
+			settingsWriter.SaveProfile(currentProfile)
+		}
+
+		if len(warnings) > 0 {
+			showConfigWarningOverlay(w, warnings)
+		}
+	}
+
+	var configWarning *widget.PopUp
+	reloadConfig := func(force bool, kept []string) {
+		data, err := os.ReadFile(configPath)
+		if err != nil || !force && settingsWriter.Matches(data) {
+			return
+		}
+		if configWarning != nil {
+			configWarning.Hide()
+			configWarning = nil
+		}
+
+		settings, warnings, err := appconfig.Load(configPath)
+		if err != nil {
+			configWarning = showWarningOverlay(w, "Configuration not reloaded",
+				fmt.Sprintf("%s changed but could not be read, so the current settings stay active:\n%v", filepath.Base(configPath), err))
+			return
+		}
+		settingsWriter.Accept(data)
+		warnings = append(warnings, applySettings(settings)...)
+		switch {
+		case len(kept) > 0:
+			text := fmt.Sprintf("%s was edited while a save was pending, so the edited values were kept: %s", filepath.Base(configPath), strings.Join(kept, ", "))
+			if len(warnings) > 0 {
+				text += "\n" + formatConfigWarnings(warnings)
+			}
+			configWarning = showWarningOverlay(w, "Configuration changed on disk", text)
+		case len(warnings) > 0:
+			configWarning = showConfigWarningOverlay(w, warnings)
+		}
+	}
+
+	if settingsWriter != nil {
+		settingsWriter.SetConflictHandler(func(keys []string) {
+			fyne.Do(func() { reloadConfig(true, keys) })
+		})
+		settingsWriter.SetErrorHandler(func(err error) {
+			fyne.Do(func() {
+				if configWarning != nil {
+					configWarning.Hide()
+				}
+				configWarning = showWarningOverlay(w, "Settings not saved",
+					fmt.Sprintf("%s could not be updated, so the last change is only active until grompt closes:\n%v", filepath.Base(configPath), err))
+			})
+		})
+		var stateWarning *widget.PopUp
+		stateWriter.SetErrorHandler(func(err error) {
+			fyne.Do(func() {
+				if stateWarning != nil && stateWarning.Visible() {
+					return
+				}
+				stateWarning = showWarningOverlay(w, "Reading position not saved", err.Error())
+			})
+		})
+		configWatcher, err := content.WatchFile(configPath, func() {
+			fyne.Do(func() { reloadConfig(false, nil) })
+		})
+		if err == nil {
+			defer configWatcher.Close()
+		} else if !errors.Is(err, os.ErrNotExist) {
+			configWarnings = append(configWarnings, fmt.Sprintf("cannot watch config file: %v", err))
+		}
+	}
+
 	showSettingsMenu := func() {
-		menu := fyne.NewMenu("Menu",
-			fyne.NewMenuItem("Load file...", openFile),
-			fyne.NewMenuItemSeparator(),
-			fyne.NewMenuItem(fmt.Sprintf("Text size + (%.0f pt)", typographyTheme.BodySize()), increaseFontSize),
-			fyne.NewMenuItem(fmt.Sprintf("Text size - (%.0f pt)", typographyTheme.BodySize()), decreaseFontSize),
-			fyne.NewMenuItemSeparator(),
+		easing, ramp := engine.Easing()
+		mirror, flip := scrollWithFade.Mirror()
+
+		profileItems := make([]*fyne.MenuItem, 0, len(profiles))
+		for _, name := range profiles {
+			name := name
+			item := fyne.NewMenuItem(name, func() {
+				switchProfile(name)
+			})
+			item.Checked = name == currentProfile
+			profileItems = append(profileItems, item)
+		}
+		profileLabel := currentProfile
+		if profileLabel == "" {
+			profileLabel = "none"
+		}
+		profileItem := fyne.NewMenuItem(fmt.Sprintf("Profile: %s", profileLabel), nil)
+		profileItem.ChildMenu = fyne.NewMenu("", profileItems...)
+		profileItem.Disabled = len(profileItems) == 0
+
+		currentFont := typographyTheme.FontName()
+		fontItems := []*fyne.MenuItem{}
+		for _, name := range fontChoices(currentFont) {
+			name := name
+			item := fyne.NewMenuItem(formatFontFamily(name), func() {
+				setFont(name)
+			})
+			item.Checked = strings.EqualFold(name, currentFont)
+			fontItems = append(fontItems, item)
+		}
+		fontItem := fyne.NewMenuItem(fmt.Sprintf("Font: %s", formatFontFamily(currentFont)), nil)
+		fontItem.ChildMenu = fyne.NewMenu("", fontItems...)
+
+		schemeItems := make([]*fyne.MenuItem, 0, len(ColorSchemes))
+		for _, scheme := range ColorSchemes {
+			scheme := scheme
+			item := fyne.NewMenuItem(formatColorScheme(scheme), func() {
+				setColorScheme(scheme)
+			})
+			item.Checked = scheme == typographyTheme.ColorScheme()
+			schemeItems = append(schemeItems, item)
+		}
+		schemeItem := fyne.NewMenuItem(fmt.Sprintf("Colours: %s", formatColorScheme(typographyTheme.ColorScheme())), nil)
+		schemeItem.ChildMenu = fyne.NewMenu("", schemeItems...)
+
+		guide := scrollWithFade.Guide()
+		guidePosition := GuidePositionTopThird
+		if guide.Position == GuidePositionTopThird {
+			guidePosition = GuidePositionCenter
+		}
+		guideItem := fyne.NewMenuItem("Reading guide", nil)
+		guideItem.ChildMenu = fyne.NewMenu("",
+			fyne.NewMenuItem(fmt.Sprintf("Position: %s", formatGuidePosition(guide.Position)), func() {
+				next := guide
+				next.Position = guidePosition
+				setGuide(next)
+			}),
+			fyne.NewMenuItem(fmt.Sprintf("Clear band + (%d lines)", guide.Lines), func() {
+				next := guide
+				next.Lines++
+				setGuide(next)
+			}),
+			fyne.NewMenuItem(fmt.Sprintf("Clear band - (%d lines)", guide.Lines), func() {
+				next := guide
+				next.Lines--
+				setGuide(next)
+			}),
+			fyne.NewMenuItem(fmt.Sprintf("Fade + (%d%%)", guide.Fade), func() {
+				next := guide
+				next.Fade += GuideFadeStep
+				setGuide(next)
+			}),
+			fyne.NewMenuItem(fmt.Sprintf("Fade - (%d%%)", guide.Fade), func() {
+				next := guide
+				next.Fade -= GuideFadeStep
+				setGuide(next)
+			}),
+			fyne.NewMenuItem(fmt.Sprintf("Marker: %s", formatGuideMarker(guide.Marker)), func() {
+				next := guide
+				next.Marker = nextGuideMarker(guide.Marker)
+				setGuide(next)
+			}),
+		)
+
+		spacingItem := fyne.NewMenuItem("Spacing", nil)
+		spacingItem.ChildMenu = fyne.NewMenu("",
 			fyne.NewMenuItem(fmt.Sprintf("Word spacing + (x%d)", wordSpacing), func() {
 				changeWordSpacing(wordSpacing + 1)
 			}),
@@ -254,6 +1088,38 @@ func Run() error {
 				changeWordSpacing(wordSpacing - 1)
 			}),
 			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(fmt.Sprintf("Line height + (x%.1f)", typographyTheme.LineHeight()), increaseLineHeight),
+			fyne.NewMenuItem(fmt.Sprintf("Line height - (x%.1f)", typographyTheme.LineHeight()), decreaseLineHeight),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(fmt.Sprintf("Letter spacing + (%.2f em)", typographyTheme.LetterSpacing()), increaseLetterSpacing),
+			fyne.NewMenuItem(fmt.Sprintf("Letter spacing - (%.2f em)", typographyTheme.LetterSpacing()), decreaseLetterSpacing),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(fmt.Sprintf("Margins + (%d%%)", typographyTheme.Margin()), increaseMargin),
+			fyne.NewMenuItem(fmt.Sprintf("Margins - (%d%%)", typographyTheme.Margin()), decreaseMargin),
+		)
+
+		menu := fyne.NewMenu("Menu",
+			fyne.NewMenuItem("Load file...", openFile),
+			profileItem,
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(fmt.Sprintf("Text size + (%.0f pt)", typographyTheme.BodySize()), increaseFontSize),
+			fyne.NewMenuItem(fmt.Sprintf("Text size - (%.0f pt)", typographyTheme.BodySize()), decreaseFontSize),
+			fontItem,
+			fyne.NewMenuItemSeparator(),
+			spacingItem,
+			schemeItem,
+			guideItem,
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(fmt.Sprintf("Speed unit: %s", formatSpeedUnit(speedUnit)), toggleSpeedUnit),
+			fyne.NewMenuItem("Target duration...", showTargetDurationDialog),
+			fyne.NewMenuItem(fmt.Sprintf("Easing: %s (%d ms)", easing, ramp/time.Millisecond), cycleEasing),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(fmt.Sprintf("Mirror horizontally: %s", formatToggle(mirror)), toggleMirror),
+			fyne.NewMenuItem(fmt.Sprintf("Flip vertically: %s", formatToggle(flip)), toggleFlip),
+			fyne.NewMenuItem(fmt.Sprintf("Talent window: %s", formatToggle(talentWindow != nil)), toggleTalentWindow),
+			fyne.NewMenuItem("Toggle full screen", toggleFullScreen),
+			fyne.NewMenuItem("Keyboard shortcuts...", toggleKeymapHelp),
+			fyne.NewMenuItemSeparator(),
 			fyne.NewMenuItem("Exit", func() {
 				a.Quit()
 			}),
@@ -265,55 +1131,151 @@ func Run() error {
 
 	controls = NewControls(ControlActions{
 		OnSettings: showSettingsMenu,
-		OnPlay: func() {
-			engine.Play()
-		},
+		OnPlay:     play,
 		OnPause: func() {
 			engine.Pause()
 		},
-		OnSpeedUp: func() {
-			controls.SetSpeed(engine.SpeedUp())
-			saveSettings()
-		},
-		OnSpeedDown: func() {
-			controls.SetSpeed(engine.SpeedDown())
-			saveSettings()
-		},
-	}, engine.Speed())
+		OnSpeedUp:   speedUp,
+		OnSpeedDown: speedDown,
+		OnRewind:    rewind,
+		OnReverse:   toggleReverse,
+	}, displayedSpeed(), speedUnit)
 
-	input.BindTeleprompterKeys(w.Canvas(), input.KeyActions{
-		OnTogglePlayPause: func() {
-			engine.Toggle()
-		},
-		OnSpeedUp: func() {
-			controls.SetSpeed(engine.SpeedUp())
-			saveSettings()
-		},
-		OnSpeedDown: func() {
-			controls.SetSpeed(engine.SpeedDown())
-			saveSettings()
-		},
-		OnFontSizeUp:   increaseFontSize,
-		OnFontSizeDown: decreaseFontSize,
+	keyActions = input.KeyActions{
+		OnTogglePlayPause:   togglePlayPause,
+		OnSpeedUp:           speedUp,
+		OnSpeedDown:         speedDown,
+		OnFontSizeUp:        increaseFontSize,
+		OnFontSizeDown:      decreaseFontSize,
+		OnWordSpacingUp:     func() { changeWordSpacing(wordSpacing + 1) },
+		OnWordSpacingDown:   func() { changeWordSpacing(wordSpacing - 1) },
+		OnLineHeightUp:      increaseLineHeight,
+		OnLineHeightDown:    decreaseLineHeight,
+		OnLetterSpacingUp:   increaseLetterSpacing,
+		OnLetterSpacingDown: decreaseLetterSpacing,
+		OnMarginUp:          increaseMargin,
+		OnMarginDown:        decreaseMargin,
+		OnRewind:            rewind,
+		OnResetToTop:        resetToTop,
+		OnNextSection:       func() { jumpToAdjacentSection(true) },
+		OnPreviousSection:   func() { jumpToAdjacentSection(false) },
+		OnToggleReverse:     toggleReverse,
+		OnToggleMirror:      toggleMirror,
+		OnToggleFlip:        toggleFlip,
+		OnToggleTalent:      toggleTalentWindow,
+		OnToggleFullScreen:  toggleFullScreen,
+		OnShowHelp:          toggleKeymapHelp,
+	}
+	input.BindTeleprompterKeys(w.Canvas(), keymap, keyActions)
+
+	onUI := func(action func()) func() {
+		return func() { fyne.Do(action) }
+	}
+	remoteActions := remote.Actions{
+		Play:            onUI(play),
+		Pause:           onUI(engine.Pause),
+		Toggle:          onUI(togglePlayPause),
+		SpeedUp:         onUI(speedUp),
+		SpeedDown:       onUI(speedDown),
+		SetSpeed:        func(speed float64) { fyne.Do(func() { setSpeed(speed) }) },
+		FontSizeUp:      onUI(increaseFontSize),
+		FontSizeDown:    onUI(decreaseFontSize),
+		WordSpacingUp:   onUI(func() { changeWordSpacing(wordSpacing + 1) }),
+		WordSpacingDown: onUI(func() { changeWordSpacing(wordSpacing - 1) }),
+		JumpToSection:   func(index int) { fyne.Do(func() { jumpToSection(index) }) },
+		LoadFile:        func(path string) { fyne.Do(func() { loadPath(path) }) },
+		FullScreen:      onUI(toggleFullScreen),
+	}
+
+	var remoteServer *remote.Server
+	if resolved.remoteAddr != "" {
+		remoteServer = remote.NewServer(remoteActions, resolved.remoteToken, resolved.scriptsDir)
+		if err := remoteServer.Start(resolved.remoteAddr); err != nil {
+			configWarnings = append(configWarnings, err.Error())
+			_ = remoteServer.Close()
+			remoteServer = nil
+		} else {
+			defer remoteServer.Close()
+		}
+	}
+
+	var oscServer *remote.OSCServer
+	if resolved.oscAddr != "" {
+		server, err := remote.NewOSCServer(remoteActions, resolved.oscFeedback, resolved.scriptsDir)
+		if err == nil {
+			err = server.Start(resolved.oscAddr)
+		}
+		if err != nil {
+			configWarnings = append(configWarnings, err.Error())
+		} else {
+			oscServer = server
+			defer oscServer.Close()
+		}
+	}
+
+	frameDriver := newFrameDriver(engine, func() {
+		updateSchedule()
+		if remoteServer != nil || oscServer != nil {
+			state := remote.State{
+				Playing:     engine.IsPlaying(),
+				Speed:       displayedSpeed(),
+				SpeedUnit:   string(speedUnit),
+				Position:    math.Round(float64(readingLineFraction(scroll, scrollWithFade.Guide().Ratio()))*1000) / 1000,
+				FontSize:    typographyTheme.BodySize(),
+				WordSpacing: wordSpacing,
+				FileName:    loadedFileName,
+				Sections:    remoteSections,
+			}
+			if remoteServer != nil {
+				remoteServer.Publish(state)
+			}
+			if oscServer != nil {
+				oscServer.Publish(state)
+			}
+		}
+		rememberDocument()
+		scrollWithFade.RefreshMirror()
+		if talentWindow != nil {
+			followScroll(scroll, previewScroll, scrollWithFade.Guide().Ratio())
+		}
 	})
+	frameDriver.Start()
+	defer frameDriver.Stop()
 
-	w.SetContent(container.NewBorder(controls.View(), nil, nil, nil, scrollWithFade))
+	w.SetContent(container.NewBorder(controls.View(), nil, nil, nil, viewport))
 	if len(configWarnings) > 0 {
 		showConfigWarningOverlay(w, configWarnings)
 	}
+	a.Lifecycle().SetOnStarted(func() {
+		if options.File != "" {
+			loadPath(options.File)
+		}
+		switch {
+		case options.Countdown > 0:
+			startCountdown(w, options.Countdown, play)
+		case options.StartPlaying:
+			play()
+		}
+	})
+	w.SetFullScreen(options.FullScreen)
 	w.ShowAndRun()
 	return nil
 }
 
-func showConfigWarningOverlay(w fyne.Window, warnings []string) {
+func showConfigWarningOverlay(w fyne.Window, warnings []string) *widget.PopUp {
+	return showWarningOverlay(w, "Configuration warning", formatConfigWarnings(warnings))
+}
+
+func formatConfigWarnings(warnings []string) string {
 	visibleWarnings := warnings
 	if len(visibleWarnings) > 4 {
-		visibleWarnings = append(visibleWarnings[:4], fmt.Sprintf("... and %d more", len(warnings)-4))
+		visibleWarnings = append(visibleWarnings[:4:4], fmt.Sprintf("... and %d more", len(warnings)-4))
 	}
+	return "Some config values were ignored:\n- " + strings.Join(visibleWarnings, "\n- ")
+}
 
-	message := widget.NewLabel(
-		"Some config values were ignored:\n- " + strings.Join(visibleWarnings, "\n- "),
-	)
+func showWarningOverlay(w fyne.Window, title, text string) *widget.PopUp {
+	message := widget.NewLabel(text)
 	message.Wrapping = fyne.TextWrapWord
 
 	var popup *widget.PopUp
@@ -324,11 +1286,42 @@ func showConfigWarningOverlay(w fyne.Window, warnings []string) {
 	})
 	closeButton.Importance = widget.LowImportance
 
-	header := container.NewBorder(nil, nil, nil, closeButton, widget.NewLabel("Configuration warning"))
+	header := container.NewBorder(nil, nil, nil, closeButton, widget.NewLabel(title))
 	contentView := container.NewBorder(header, nil, nil, nil, message)
 
 	popup = widget.NewPopUp(contentView, w.Canvas())
 	popup.Resize(fyne.NewSize(460, 160))
 	popup.Move(fyne.NewPos(16, 16))
 	popup.Show()
+	return popup
+}
+
+func startCountdown(w fyne.Window, duration time.Duration, done func()) {
+	remaining := int(math.Ceil(duration.Seconds()))
+	number := canvas.NewText(strconv.Itoa(remaining), theme.Color(theme.ColorNameForeground))
+	number.TextSize = countdownTextSize
+	number.TextStyle = fyne.TextStyle{Bold: true}
+	number.Alignment = fyne.TextAlignCenter
+
+	popup := widget.NewModalPopUp(container.NewPadded(number), w.Canvas())
+	popup.Show()
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for remaining > 1 {
+			<-ticker.C
+			remaining--
+			next := strconv.Itoa(remaining)
+			fyne.Do(func() {
+				number.Text = next
+				number.Refresh()
+			})
+		}
+		<-ticker.C
+		fyne.Do(func() {
+			popup.Hide()
+			done()
+		})
+	}()
 }